@@ -0,0 +1,166 @@
+package sqb
+
+import "fmt"
+
+const batchAlias = "sqb_batch"
+
+// BatchUpdateStmt updates many rows matched by Key, each row with its own values.
+// PostgreSQL gets UPDATE ... FROM (VALUES ...), other dialects get CASE per column.
+type BatchUpdateStmt struct {
+	Table   TableIdentifier
+	Key     Column
+	Columns []Column
+	// Rows are key value followed by values of Columns.
+	Rows [][]interface{}
+	// Types are SQL types of Key and Columns, required by PostgreSQL
+	// to resolve parameters in VALUES, other dialects ignore them.
+	Types []string
+	// MaxArgs overrides bind parameters limit of dialect in Queries.
+	MaxArgs int
+	// WhereStmt holds conditions ANDed with key match, such as Rewriter predicates.
+	WhereStmt WhereStmt
+}
+
+func BatchUpdate(table TableIdentifier, key Column, columns []Column, rows [][]interface{}) BatchUpdateStmt {
+	return BatchUpdateStmt{
+		Table:   table,
+		Key:     key,
+		Columns: columns,
+		Rows:    rows,
+	}
+}
+
+func (bu BatchUpdateStmt) WriteSQLTo(w SQLWriter) error {
+	us, err := bu.updateStmt(dialectOf(w))
+	if err != nil {
+		return err
+	}
+	return us.WriteSQLTo(w)
+}
+
+// Queries splits rows into statements not exceeding bind parameters limit
+// and renders them in dialect d.
+func (bu BatchUpdateStmt) Queries(d Dialect) ([]Query, error) {
+	limit := bu.MaxArgs
+	if limit == 0 {
+		limit = d.MaxArgs()
+	}
+
+	perRow := 2*len(bu.Columns) + 1
+	if usesValuesUpdate(d) {
+		perRow = len(bu.Columns) + 1
+	}
+
+	base, err := countArgs(d, bu.WhereStmt)
+	if err != nil {
+		return nil, err
+	}
+
+	chunk := len(bu.Rows)
+	if limit > 0 {
+		chunk = (limit - base) / perRow
+		if chunk <= 0 {
+			return nil, invalid(bu, fmt.Sprintf("row needs %d args and conditions %d, limit is %d", perRow, base, limit))
+		}
+	}
+
+	var queries []Query
+	for start := 0; start < len(bu.Rows); start += chunk {
+		end := start + chunk
+		if end > len(bu.Rows) {
+			end = len(bu.Rows)
+		}
+
+		part := bu
+		part.Rows = bu.Rows[start:end]
+		sql, args, err := d.ToSQL(part)
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, Query{SQL: sql, Args: args})
+	}
+	return queries, nil
+}
+
+func usesValuesUpdate(d Dialect) bool {
+	_, ok := d.(PostgreSQLDialect)
+	return ok
+}
+
+func (bu BatchUpdateStmt) updateStmt(d Dialect) (UpdateStmt, error) {
+	if len(bu.Rows) == 0 {
+		return UpdateStmt{}, invalid(bu, "batch update must have at least one row")
+	}
+
+	if len(bu.Types) != 0 && len(bu.Types) != len(bu.Columns)+1 {
+		return UpdateStmt{}, invalid(bu, fmt.Sprintf("%d types, expected %d", len(bu.Types), len(bu.Columns)+1))
+	}
+
+	for i, row := range bu.Rows {
+		if len(row) != len(bu.Columns)+1 {
+			return UpdateStmt{}, invalid(bu, fmt.Sprintf("row %d has %d values, expected %d", i, len(row), len(bu.Columns)+1))
+		}
+	}
+
+	if usesValuesUpdate(d) {
+		if len(bu.Types) == 0 {
+			return UpdateStmt{}, invalid(bu, "types of key and columns are required by PostgreSQL")
+		}
+		return bu.valuesUpdate(), nil
+	}
+	return bu.caseUpdate(), nil
+}
+
+func (bu BatchUpdateStmt) valuesUpdate() UpdateStmt {
+	values := make(InsertValuesStmt, 0, len(bu.Rows))
+	for _, row := range bu.Rows {
+		line := make([]InsertValue, 0, len(row))
+		for i, v := range row {
+			line = append(line, Cast(Arg{V: v}, bu.Types[i]))
+		}
+		values = append(values, line)
+	}
+
+	set := make(SetStmt, 0, len(bu.Columns))
+	for _, c := range bu.Columns {
+		set = append(set, SetArg{Key: c, Value: Column(batchAlias + "." + string(c))})
+	}
+
+	return UpdateStmt{
+		Table: bu.Table,
+		Set:   set,
+		From: UpdateFromStmt{
+			Table: values.As(batchAlias, append([]Column{bu.Key}, bu.Columns...)...),
+			On:    Eq(Column(string(bu.Table)+"."+string(bu.Key)), Column(batchAlias+"."+string(bu.Key))),
+		},
+		WhereStmt: bu.WhereStmt,
+	}
+}
+
+func (bu BatchUpdateStmt) caseUpdate() UpdateStmt {
+	set := make(SetStmt, 0, len(bu.Columns))
+	for i, c := range bu.Columns {
+		ce := CaseExpr{
+			Operand: bu.Key,
+			Whens:   make([]WhenClause, 0, len(bu.Rows)),
+			Else:    c,
+		}
+		for _, row := range bu.Rows {
+			ce.Whens = append(ce.Whens, WhenClause{When: Arg{V: row[0]}, Then: Arg{V: row[i+1]}})
+		}
+		set = append(set, SetArg{Key: c, Value: ce})
+	}
+
+	keys := make([]Comparable, 0, len(bu.Rows))
+	for _, row := range bu.Rows {
+		keys = append(keys, Arg{V: row[0]})
+	}
+
+	exprs := make([]BoolExpr, 0, len(bu.WhereStmt.Exprs)+1)
+	exprs = append(exprs, In(bu.Key, keys...))
+	return UpdateStmt{
+		Table:     bu.Table,
+		Set:       set,
+		WhereStmt: WhereStmt{Exprs: append(exprs, bu.WhereStmt.Exprs...)},
+	}
+}
@@ -0,0 +1,121 @@
+package sqb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchUpdateStmt_WriteSQLTo(t *testing.T) {
+	rows := [][]interface{}{
+		{1, "alice"},
+		{2, "bob"},
+	}
+	tests := []struct {
+		name           string
+		sqb            SQB
+		dialect        Dialect
+		wantErr        bool
+		expectedRawSQL string
+		expectedArgs   []interface{}
+	}{
+		{
+			name:           "case update",
+			sqb:            BatchUpdate(TableName("users"), Column("id"), []Column{Column("name")}, rows),
+			dialect:        DefaultDialect{},
+			expectedRawSQL: "UPDATE users SET name = CASE id WHEN ? THEN ? WHEN ? THEN ? ELSE name END WHERE (id IN (?, ?))",
+			expectedArgs:   []interface{}{1, "alice", 2, "bob", 1, 2},
+		},
+		{
+			name:           "mysql case update",
+			sqb:            BatchUpdate(TableName("users"), Column("id"), []Column{Column("name")}, rows[:1]),
+			dialect:        MySQLDialect{},
+			expectedRawSQL: "UPDATE users SET name = CASE id WHEN ? THEN ? ELSE name END WHERE (id IN (?))",
+			expectedArgs:   []interface{}{1, "alice", 1},
+		},
+		{
+			name:    "postgresql values update without types",
+			sqb:     BatchUpdate(TableName("users"), Column("id"), []Column{Column("name")}, rows),
+			dialect: PostgreSQLDialect{},
+			wantErr: true,
+		},
+		{
+			name: "postgresql values update with types",
+			sqb: BatchUpdateStmt{
+				Table:   TableName("users"),
+				Key:     Column("id"),
+				Columns: []Column{Column("name")},
+				Rows:    rows[:1],
+				Types:   []string{"bigint", "text"},
+			},
+			dialect:        PostgreSQLDialect{},
+			expectedRawSQL: "UPDATE users SET name = sqb_batch.name FROM (VALUES (CAST($1 AS bigint), CAST($2 AS text))) AS sqb_batch(id, name) WHERE (users.id=sqb_batch.id)",
+			expectedArgs:   []interface{}{1, "alice"},
+		},
+		{
+			name:    "row length mismatch",
+			sqb:     BatchUpdate(TableName("users"), Column("id"), []Column{Column("name")}, [][]interface{}{{1}}),
+			dialect: DefaultDialect{},
+			wantErr: true,
+		},
+		{
+			name:    "no rows",
+			sqb:     BatchUpdate(TableName("users"), Column("id"), []Column{Column("name")}, nil),
+			dialect: DefaultDialect{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builded, args, err := tt.dialect.ToSQL(tt.sqb)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("WriteSQLTo() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if builded != tt.expectedRawSQL {
+				t.Errorf("WriteSQLTo() raw SQL expected = %v, actual = %v", tt.expectedRawSQL, builded)
+			}
+			assert.Equal(t, tt.expectedArgs, args)
+			assertRoundTrip(t, tt.dialect, tt.sqb)
+		})
+	}
+}
+
+func TestBatchUpdateStmt_Queries(t *testing.T) {
+	bu := BatchUpdate(TableName("users"), Column("id"), []Column{Column("name")}, [][]interface{}{
+		{1, "a"}, {2, "b"}, {3, "c"},
+	})
+	bu.MaxArgs = 4
+	bu.Types = []string{"int", "text"}
+
+	queries, err := bu.Queries(PostgreSQLDialect{})
+	assert.NoError(t, err)
+	assert.Equal(t, []Query{
+		{
+			SQL:  "UPDATE users SET name = sqb_batch.name FROM (VALUES (CAST($1 AS int), CAST($2 AS text)), (CAST($3 AS int), CAST($4 AS text))) AS sqb_batch(id, name) WHERE (users.id=sqb_batch.id)",
+			Args: []interface{}{1, "a", 2, "b"},
+		},
+		{
+			SQL:  "UPDATE users SET name = sqb_batch.name FROM (VALUES (CAST($1 AS int), CAST($2 AS text))) AS sqb_batch(id, name) WHERE (users.id=sqb_batch.id)",
+			Args: []interface{}{3, "c"},
+		},
+	}, queries)
+
+	queries, err = bu.Queries(MySQLDialect{})
+	assert.NoError(t, err)
+	assert.Len(t, queries, 3)
+
+	bu.MaxArgs = 2
+	_, err = bu.Queries(MySQLDialect{})
+	assert.Error(t, err)
+
+	bu.MaxArgs = 5
+	bu.WhereStmt = WhereStmt{Exprs: []BoolExpr{Eq(Column("users.tenant_id"), Arg{V: 7})}}
+	queries, err = bu.Queries(PostgreSQLDialect{})
+	assert.NoError(t, err)
+	assert.Len(t, queries, 2)
+	assert.Equal(t, "UPDATE users SET name = sqb_batch.name FROM (VALUES (CAST($1 AS int), CAST($2 AS text)), (CAST($3 AS int), CAST($4 AS text))) AS sqb_batch(id, name) WHERE (users.id=sqb_batch.id) AND (users.tenant_id=$5)", queries[0].SQL)
+	assert.Equal(t, []interface{}{1, "a", 2, "b", 7}, queries[0].Args)
+}
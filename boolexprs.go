@@ -8,6 +8,7 @@ type EqExpr struct {
 	A, B Comparable
 }
 
+// Eq accepts operands of any types, EqOf and methods of TypedColumn check them at compile time.
 func Eq(a, b Comparable) EqExpr {
 	return EqExpr{A: a, B: b}
 }
@@ -177,7 +178,7 @@ func (b BinaryOperator) WriteSQLTo(w SQLWriter) error {
 		return err
 	}
 
-	_, err = w.WriteString(" " + b.Op + " ")
+	err = writeStrings(w, " ", b.Op, " ")
 	if err != nil {
 		return err
 	}
@@ -189,6 +190,8 @@ func (b BinaryOperator) WriteSQLTo(w SQLWriter) error {
 	return nil
 }
 
+// BinaryOp accepts operands of any types, comparisons of typed operands are
+// checked by LtOf, GtOf and the like, or methods of TypedColumn.
 func BinaryOp(left Comparable, op string, right Comparable) BinaryOperator {
 	return BinaryOperator{
 		Left:  left,
@@ -240,3 +243,54 @@ func (ne NotExpr) WriteSQLTo(w SQLWriter) error {
 	_, err = w.WriteString(`)`)
 	return err
 }
+
+type InExpr struct {
+	A    Comparable
+	List []Comparable
+}
+
+// In accepts operands of any types, InOf and TypedColumn.In check them at compile time.
+func In(a Comparable, list ...Comparable) InExpr {
+	return InExpr{
+		A:    a,
+		List: list,
+	}
+}
+
+func (InExpr) IsOnExpr() {}
+
+func (ie InExpr) WriteSQLTo(w SQLWriter) error {
+	if len(ie.List) == 0 {
+		return invalid(ie, "IN list must not be empty")
+	}
+
+	err := ie.A.WriteSQLTo(w)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.WriteString(` IN (`)
+	if err != nil {
+		return err
+	}
+
+	err = ie.List[0].WriteSQLTo(w)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range ie.List[1:] {
+		_, err = w.WriteString(`, `)
+		if err != nil {
+			return err
+		}
+
+		err = c.WriteSQLTo(w)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = w.WriteString(`)`)
+	return err
+}
@@ -50,6 +50,48 @@ func Test_NotExpr(t *testing.T) {
 				t.Errorf("WriteSQLTo() raw SQL expected = %v, actual = %v", tt.expectedRawSQL, builded)
 			}
 			assert.Equal(t, tt.expectedArgs, tsw.Args)
+			assertRoundTrip(t, DefaultDialect{}, tt.sqb)
+		})
+	}
+}
+
+func Test_InExpr(t *testing.T) {
+	var tests = []struct {
+		name           string
+		sqb            SQB
+		wantErr        bool
+		expectedRawSQL string
+		expectedArgs   []interface{}
+	}{
+		{
+			name:           "in args",
+			expectedRawSQL: "SELECT * FROM users WHERE (id IN (?, ?))",
+			expectedArgs:   []interface{}{1, 2},
+			sqb:            From(TableName("users")).Where(In(Column("id"), Arg{V: 1}, Arg{V: 2})),
+		},
+		{
+			name:    "empty in",
+			sqb:     From(TableName("users")).Where(In(Column("id"))),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqb := tt.sqb
+			tsw := &DefaultSQLWriter{}
+			err := sqb.WriteSQLTo(tsw)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("WriteSQLTo() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			builded := tsw.String()
+			if builded != tt.expectedRawSQL {
+				t.Errorf("WriteSQLTo() raw SQL expected = %v, actual = %v", tt.expectedRawSQL, builded)
+			}
+			assert.Equal(t, tt.expectedArgs, tsw.Args)
+			assertRoundTrip(t, DefaultDialect{}, tt.sqb)
 		})
 	}
 }
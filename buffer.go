@@ -0,0 +1,160 @@
+package sqb
+
+import (
+	"strconv"
+	"sync"
+)
+
+// Buffer is SQLWriter of dialect D appending SQL to B and arguments to Args.
+// Unlike writers built on strings.Builder it can be reused after Reset,
+// so rendering into pre-sized Buffer does not allocate for SQL text.
+type Buffer struct {
+	B    []byte
+	Args []interface{}
+	D    Dialect
+
+	names namedArgs
+}
+
+// placeholderDialect is implemented by dialects with placeholders other than ?.
+type placeholderDialect interface {
+	// appendPlaceholder appends placeholder of n-th argument, counting from 1.
+	appendPlaceholder(b []byte, n int) []byte
+}
+
+func (PostgreSQLDialect) appendPlaceholder(b []byte, n int) []byte {
+	return strconv.AppendInt(append(b, '$'), int64(n), 10)
+}
+
+func appendPlaceholder(d Dialect, b []byte, n int) []byte {
+	if pd, ok := d.(placeholderDialect); ok {
+		return pd.appendPlaceholder(b, n)
+	}
+	return append(b, '?')
+}
+
+func (b *Buffer) WriteString(s string) (int, error) {
+	b.B = append(b.B, s...)
+	return len(s), nil
+}
+
+func (b *Buffer) Write(p []byte) (int, error) {
+	b.B = append(b.B, p...)
+	return len(p), nil
+}
+
+func (b *Buffer) AddArgs(a interface{}) error {
+	b.Args = append(b.Args, a)
+	b.B = appendPlaceholder(b.D, b.B, len(b.Args))
+	return nil
+}
+
+func (b *Buffer) AddNamedArg(name string, v interface{}) error {
+	var ok bool
+	var err error
+	b.B, b.Args, ok, err = b.names.bind(b.D, b.B, b.Args, name, v)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return b.AddArgs(v)
+	}
+	return nil
+}
+
+func (b *Buffer) AppendRawArgs(a ...interface{}) error {
+	b.Args = append(b.Args, a...)
+	return nil
+}
+
+func (b *Buffer) Dialect() Dialect {
+	if b.D == nil {
+		return DefaultDialect{}
+	}
+	return b.D
+}
+
+func (b *Buffer) Len() int {
+	return len(b.B)
+}
+
+func (b *Buffer) String() string {
+	return string(b.B)
+}
+
+// Reset empties buffer keeping its capacity.
+func (b *Buffer) Reset() {
+	for i := range b.Args {
+		b.Args[i] = nil
+	}
+	b.B = b.B[:0]
+	b.Args = b.Args[:0]
+	b.names.reset()
+}
+
+func (b *Buffer) query() Query {
+	return Query{SQL: b.String(), Args: b.Args}
+}
+
+// ToSQLInto renders s in dialect d appending SQL to buf and arguments to args.
+// Placeholders are numbered after arguments already in args.
+// Unlike ToSQL it does not validate s, which allocates for every node of struct type,
+// so statement rendered repeatedly is checked by Validate once.
+func ToSQLInto(d Dialect, s SQB, buf []byte, args []interface{}) ([]byte, []interface{}, error) {
+	b := bufferPool.Get().(*Buffer)
+	pooledB, pooledArgs := b.B, b.Args
+	b.B, b.Args, b.D = buf, args, d
+	err := s.WriteSQLTo(b)
+	buf, args = b.B, b.Args
+	b.B, b.Args = pooledB, pooledArgs
+	putBuffer(b)
+	return buf, args, err
+}
+
+const (
+	bufferSize = 256
+	bufferArgs = 16
+	// bufferMaxSize keeps huge statements from pinning memory in pool.
+	bufferMaxSize = 64 << 10
+)
+
+var bufferPool = sync.Pool{
+	New: func() interface{} {
+		return &Buffer{
+			B:    make([]byte, 0, bufferSize),
+			Args: make([]interface{}, 0, bufferArgs),
+		}
+	},
+}
+
+// toSQL validates and renders s in dialect d with pooled Buffer.
+func toSQL(d Dialect, s SQB) (string, []interface{}, error) {
+	err := Validate(s)
+	if err != nil {
+		return "", nil, err
+	}
+
+	b := bufferPool.Get().(*Buffer)
+	b.D = d
+	defer putBuffer(b)
+
+	err = s.WriteSQLTo(b)
+	if err != nil {
+		return "", nil, err
+	}
+	var args []interface{}
+	if len(b.Args) > 0 {
+		args = make([]interface{}, len(b.Args))
+		copy(args, b.Args)
+	}
+	return string(b.B), args, nil
+}
+
+func putBuffer(b *Buffer) {
+	if cap(b.B) > bufferMaxSize {
+		return
+	}
+	b.Reset()
+	b.D = nil
+	bufferPool.Put(b)
+}
@@ -0,0 +1,120 @@
+package sqb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToSQLInto(t *testing.T) {
+	s := From(TableName("users")).Where(Eq(Column("id"), Arg{V: 1}), Eq(Column("role"), Arg{V: "admin"}))
+
+	buf, args, err := ToSQLInto(PostgreSQLDialect{}, s, []byte("/* q */ "), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "/* q */ SELECT * FROM users WHERE (id=$1) AND (role=$2)", string(buf))
+	assert.Equal(t, []interface{}{1, "admin"}, args)
+
+	buf, args, err = ToSQLInto(PostgreSQLDialect{}, s, append(buf, "; "...), args)
+	assert.NoError(t, err)
+	assert.Equal(t, "/* q */ SELECT * FROM users WHERE (id=$1) AND (role=$2); SELECT * FROM users WHERE (id=$3) AND (role=$4)", string(buf))
+	assert.Equal(t, []interface{}{1, "admin", 1, "admin"}, args)
+
+	buf, args, err = ToSQLInto(MySQLDialect{}, s, buf[:0], args[:0])
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM users WHERE (id=?) AND (role=?)", string(buf))
+	assert.Equal(t, []interface{}{1, "admin"}, args)
+
+	_, _, err = ToSQLInto(MySQLDialect{}, SelectStmt{}, nil, nil)
+	assert.Error(t, err)
+}
+
+func TestBuffer_Reset(t *testing.T) {
+	b := &Buffer{}
+	assert.Equal(t, DefaultDialect{}, b.Dialect())
+	assert.NoError(t, Arg{V: 1}.WriteSQLTo(b))
+	assert.Equal(t, "?", b.String())
+
+	b.Reset()
+	assert.Equal(t, 0, b.Len())
+	assert.Empty(t, b.Args)
+	assert.Nil(t, b.Args[:1][0])
+}
+
+var benchmarkStatements = []struct {
+	name string
+	sqb  SQB
+}{
+	{
+		name: "select",
+		sqb: From(LeftJoin(TableName("users").As("u"), TableName("posts").As("p"), Eq(Column("u.id"), Column("p.user_id")))).
+			Select(Column("u.id"), Column("u.name"), Count(Column("p.id")).Distinct()).
+			Where(Eq(Column("u.city"), Arg{V: 10}), In(Column("u.role"), Arg{V: "admin"}, Arg{V: "editor"})).
+			GroupBy(Column("u.id"), Column("u.name")).
+			OrderBy(Desc(Column("u.name"))).
+			Limit(20).Offset(40),
+	},
+	{
+		name: "insert",
+		sqb: Insert(TableName("users"), []Column{"name", "email", "city"}, InsertValuesStmt{
+			{Arg{V: "alice"}, Arg{V: "alice@example.com"}, Arg{V: 10}},
+			{Arg{V: "bob"}, Arg{V: "bob@example.com"}, Default},
+		}).Returning(Column("id")),
+	},
+	{
+		name: "update",
+		sqb: UpdateStmt{
+			Table: TableName("users"),
+			Set: SetStmt{
+				{Key: "name", Value: Arg{V: "alice"}},
+				{Key: "email", Value: Arg{V: "alice@example.com"}},
+			},
+			WhereStmt: WhereStmt{Exprs: []BoolExpr{Eq(Column("id"), Arg{V: 1})}},
+		},
+	},
+}
+
+// BenchmarkToSQL reports allocations of rendering typical statements
+// by writer built on strings.Builder and by reused buffer of ToSQLInto, neither validates,
+// cost of Validate alone, and pooled ToPostgreSql, which validates and copies result out of pool.
+func BenchmarkToSQL(b *testing.B) {
+	for _, bs := range benchmarkStatements {
+		s := bs.sqb
+		b.Run(bs.name+"/writer", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				w := &PostgreSQLWriter{}
+				if err := s.WriteSQLTo(w); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(bs.name+"/validate", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if err := Validate(s); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(bs.name+"/pooled", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, _, err := ToPostgreSql(s); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(bs.name+"/into", func(b *testing.B) {
+			buf := make([]byte, 0, 512)
+			args := make([]interface{}, 0, 16)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				var err error
+				buf, args, err = ToSQLInto(PostgreSQLDialect{}, s, buf[:0], args[:0])
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,382 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	identToken tokenKind = iota
+	quotedToken
+	literalToken
+	punctToken
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+func (t token) is(words ...string) bool {
+	if t.kind != identToken && t.kind != punctToken {
+		return false
+	}
+	for _, w := range words {
+		if strings.EqualFold(t.text, w) {
+			return true
+		}
+	}
+	return false
+}
+
+func tokenize(src string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case unicode.IsSpace(rune(c)):
+			i++
+		case strings.HasPrefix(src[i:], "--"), c == '#':
+			end := strings.IndexByte(src[i:], '\n')
+			if end < 0 {
+				return tokens, nil
+			}
+			i += end + 1
+		case strings.HasPrefix(src[i:], "/*"):
+			end := strings.Index(src[i+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated comment")
+			}
+			i += end + 4
+		case c == '"' || c == '`' || c == '\'':
+			closing := c
+			kind := quotedToken
+			if c == '\'' {
+				kind = literalToken
+			}
+			var sb strings.Builder
+			j := i + 1
+			for ; j < len(src); j++ {
+				if src[j] == closing {
+					if j+1 < len(src) && src[j+1] == closing {
+						sb.WriteByte(closing)
+						j++
+						continue
+					}
+					break
+				}
+				sb.WriteByte(src[j])
+			}
+			if j >= len(src) {
+				return nil, fmt.Errorf("unterminated quoted identifier or string")
+			}
+			tokens = append(tokens, token{kind: kind, text: sb.String()})
+			i = j + 1
+		case c == '_' || unicode.IsLetter(rune(c)) || unicode.IsDigit(rune(c)):
+			j := i
+			for j < len(src) && (src[j] == '_' || src[j] == '$' || unicode.IsLetter(rune(src[j])) || unicode.IsDigit(rune(src[j]))) {
+				j++
+			}
+			tokens = append(tokens, token{kind: identToken, text: src[i:j]})
+			i = j
+		default:
+			tokens = append(tokens, token{kind: punctToken, text: string(c)})
+			i++
+		}
+	}
+	return tokens, nil
+}
+
+// parseDDL reads CREATE TABLE statements of src, other statements are skipped.
+func parseDDL(src string) (Schema, error) {
+	tokens, err := tokenize(src)
+	if err != nil {
+		return Schema{}, err
+	}
+
+	var schema Schema
+	p := &ddlParser{tokens: tokens}
+	for !p.eof() {
+		if !p.peek().is("CREATE") {
+			p.skipStatement()
+			continue
+		}
+		p.next()
+		for p.peek().is("TEMP", "TEMPORARY", "UNLOGGED", "GLOBAL", "LOCAL") {
+			p.next()
+		}
+		if !p.peek().is("TABLE") {
+			p.skipStatement()
+			continue
+		}
+		p.next()
+
+		t, err := p.table()
+		if err != nil {
+			return Schema{}, err
+		}
+		schema.Tables = append(schema.Tables, t)
+		p.skipStatement()
+	}
+	return schema, nil
+}
+
+type ddlParser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *ddlParser) eof() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *ddlParser) peek() token {
+	if p.eof() {
+		return token{kind: punctToken}
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *ddlParser) next() token {
+	t := p.peek()
+	p.pos++
+	return t
+}
+
+func (p *ddlParser) expect(text string) error {
+	t := p.next()
+	if !t.is(text) {
+		return fmt.Errorf("expected %q, got %q", text, t.text)
+	}
+	return nil
+}
+
+func (p *ddlParser) skipStatement() {
+	for !p.eof() {
+		if p.next().is(";") {
+			return
+		}
+	}
+}
+
+// skipParens skips balanced parentheses starting at current token.
+func (p *ddlParser) skipParens() string {
+	var parts []string
+	depth := 0
+	for !p.eof() {
+		t := p.next()
+		parts = append(parts, t.text)
+		switch {
+		case t.is("("):
+			depth++
+		case t.is(")"):
+			depth--
+		}
+		if depth == 0 {
+			break
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+func (p *ddlParser) ident() (string, error) {
+	t := p.next()
+	if t.kind != identToken && t.kind != quotedToken {
+		return "", fmt.Errorf("expected identifier, got %q", t.text)
+	}
+	return t.text, nil
+}
+
+func (p *ddlParser) qualifiedName() (string, error) {
+	name, err := p.ident()
+	if err != nil {
+		return "", err
+	}
+	for p.peek().is(".") {
+		p.next()
+		part, err := p.ident()
+		if err != nil {
+			return "", err
+		}
+		name += "." + part
+	}
+	return name, nil
+}
+
+func (p *ddlParser) table() (Table, error) {
+	if p.peek().is("IF") {
+		p.next()
+		for _, w := range []string{"NOT", "EXISTS"} {
+			if err := p.expect(w); err != nil {
+				return Table{}, err
+			}
+		}
+	}
+
+	name, err := p.qualifiedName()
+	if err != nil {
+		return Table{}, err
+	}
+	t := Table{Name: name}
+
+	if err := p.expect("("); err != nil {
+		return Table{}, fmt.Errorf("table %s: %v", name, err)
+	}
+
+	var primaryKey []string
+	for {
+		if p.peek().is("CONSTRAINT", "PRIMARY", "UNIQUE", "FOREIGN", "CHECK", "KEY", "INDEX", "EXCLUDE", "FULLTEXT", "SPATIAL") {
+			cols, err := p.tableConstraint()
+			if err != nil {
+				return Table{}, fmt.Errorf("table %s: %v", name, err)
+			}
+			primaryKey = append(primaryKey, cols...)
+		} else {
+			c, err := p.column()
+			if err != nil {
+				return Table{}, fmt.Errorf("table %s: %v", name, err)
+			}
+			t.Columns = append(t.Columns, c)
+		}
+
+		sep := p.next()
+		if sep.is(")") {
+			break
+		}
+		if !sep.is(",") {
+			return Table{}, fmt.Errorf("table %s: expected \",\" or \")\", got %q", name, sep.text)
+		}
+	}
+
+	for _, pk := range primaryKey {
+		for i := range t.Columns {
+			if t.Columns[i].Name == pk {
+				t.Columns[i].PrimaryKey = true
+				t.Columns[i].Nullable = false
+			}
+		}
+	}
+	return t, nil
+}
+
+// tableConstraint skips table constraint and returns primary key columns if it declares them.
+func (p *ddlParser) tableConstraint() ([]string, error) {
+	var pk []string
+	for !p.eof() && !p.peek().is(",", ")") {
+		t := p.next()
+		if t.is("PRIMARY") && p.peek().is("KEY") {
+			p.next()
+			if err := p.expect("("); err != nil {
+				return nil, err
+			}
+			for {
+				name, err := p.ident()
+				if err != nil {
+					return nil, err
+				}
+				pk = append(pk, name)
+				if p.peek().is("(") {
+					p.skipParens()
+				}
+				for p.peek().is("ASC", "DESC") {
+					p.next()
+				}
+				if p.next().is(")") {
+					break
+				}
+			}
+			continue
+		}
+		if t.is("(") {
+			p.pos--
+			p.skipParens()
+		}
+	}
+	return pk, nil
+}
+
+var columnConstraints = []string{
+	"NOT", "NULL", "PRIMARY", "DEFAULT", "UNIQUE", "REFERENCES", "CHECK", "CONSTRAINT",
+	"GENERATED", "COLLATE", "AUTO_INCREMENT", "AUTOINCREMENT", "IDENTITY", "COMMENT", "ON", "AS",
+}
+
+func (p *ddlParser) column() (Column, error) {
+	name, err := p.ident()
+	if err != nil {
+		return Column{}, err
+	}
+	c := Column{Name: name, Nullable: true}
+
+	var typ []string
+	for !p.eof() && !p.peek().is(",", ")") && !p.peek().is(columnConstraints...) {
+		if p.peek().is("(") {
+			typ = append(typ, p.skipParens())
+			continue
+		}
+		if p.peek().is("[") {
+			p.next()
+			if p.peek().is("]") {
+				p.next()
+			}
+			typ = append(typ, "[]")
+			continue
+		}
+		typ = append(typ, strings.ToLower(p.next().text))
+	}
+	c.Type = strings.Replace(strings.Join(typ, " "), " (", "(", -1)
+	c.Type = strings.Replace(c.Type, " []", "[]", -1)
+	if c.Type == "" {
+		return Column{}, fmt.Errorf("column %s: no type", name)
+	}
+	if strings.HasSuffix(c.Type, "serial") {
+		c.Default = true
+	}
+
+	for !p.eof() && !p.peek().is(",", ")") {
+		t := p.next()
+		switch {
+		case t.is("NOT") && p.peek().is("NULL"):
+			p.next()
+			c.Nullable = false
+		case t.is("PRIMARY"):
+			c.PrimaryKey = true
+			c.Nullable = false
+		case t.is("DEFAULT", "AUTO_INCREMENT", "AUTOINCREMENT", "IDENTITY"):
+			c.Default = true
+		case t.is("GENERATED"):
+			if p.generated() {
+				c.Generated = true
+			} else {
+				c.Default = true
+			}
+		case t.is("AS") && p.peek().is("("):
+			p.skipParens()
+			c.Generated = true
+		case t.is("("):
+			p.pos--
+			p.skipParens()
+		}
+	}
+	return c, nil
+}
+
+// generated reports whether GENERATED clause declares computed column rather than identity.
+func (p *ddlParser) generated() bool {
+	for !p.eof() && !p.peek().is(",", ")") {
+		t := p.next()
+		if t.is("IDENTITY") {
+			if p.peek().is("(") {
+				p.skipParens()
+			}
+			return false
+		}
+		if t.is("AS") && p.peek().is("(") {
+			p.skipParens()
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDDL(t *testing.T) {
+	tests := []struct {
+		name     string
+		ddl      string
+		wantErr  bool
+		expected Schema
+	}{
+		{
+			name: "postgresql",
+			ddl: `-- users of service
+				CREATE TABLE IF NOT EXISTS public.users (
+					id bigserial PRIMARY KEY,
+					"name" varchar(255) NOT NULL,
+					email text,
+					created_at timestamp with time zone NOT NULL DEFAULT now(),
+					tags text[],
+					full_name text GENERATED ALWAYS AS (name || email) STORED,
+					CONSTRAINT users_email UNIQUE (email)
+				);
+				CREATE INDEX users_name ON users(name);`,
+			expected: Schema{Tables: []Table{{
+				Name: "public.users",
+				Columns: []Column{
+					{Name: "id", Type: "bigserial", PrimaryKey: true, Default: true},
+					{Name: "name", Type: "varchar(255)"},
+					{Name: "email", Type: "text", Nullable: true},
+					{Name: "created_at", Type: "timestamp with time zone", Default: true},
+					{Name: "tags", Type: "text[]", Nullable: true},
+					{Name: "full_name", Type: "text", Nullable: true, Generated: true},
+				},
+			}}},
+		},
+		{
+			name: "mysql",
+			ddl: "CREATE TABLE `posts` (\n" +
+				"  `id` int unsigned NOT NULL AUTO_INCREMENT,\n" +
+				"  user_id bigint NOT NULL,\n" +
+				"  price decimal(10, 2) DEFAULT '0.00',\n" +
+				"  PRIMARY KEY (`id`),\n" +
+				"  KEY user_idx (user_id)\n" +
+				") ENGINE=InnoDB; /* done */",
+			expected: Schema{Tables: []Table{{
+				Name: "posts",
+				Columns: []Column{
+					{Name: "id", Type: "int unsigned", PrimaryKey: true, Default: true},
+					{Name: "user_id", Type: "bigint"},
+					{Name: "price", Type: "decimal(10,2)", Nullable: true, Default: true},
+				},
+			}}},
+		},
+		{
+			name: "identity",
+			ddl:  "create table t (id int generated by default as identity (start with 10), v int)",
+			expected: Schema{Tables: []Table{{
+				Name: "t",
+				Columns: []Column{
+					{Name: "id", Type: "int", Nullable: true, Default: true},
+					{Name: "v", Type: "int", Nullable: true},
+				},
+			}}},
+		},
+		{
+			name:    "missing type",
+			ddl:     "CREATE TABLE t (id)",
+			wantErr: true,
+		},
+		{
+			name:    "unterminated",
+			ddl:     "CREATE TABLE t (id int",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema, err := parseDDL(tt.ddl)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseDDL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			assert.Equal(t, tt.expected, schema)
+		})
+	}
+}
@@ -0,0 +1,278 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+var initialisms = map[string]string{
+	"id": "ID", "url": "URL", "uri": "URI", "uuid": "UUID", "api": "API", "ip": "IP",
+	"http": "HTTP", "json": "JSON", "sql": "SQL", "html": "HTML", "xml": "XML",
+}
+
+// goName converts snake_case name to exported Go identifier.
+func goName(name string) string {
+	var sb strings.Builder
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if up, ok := initialisms[strings.ToLower(part)]; ok {
+			sb.WriteString(up)
+			continue
+		}
+		sb.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	s := sb.String()
+	if s == "" || unicode.IsDigit(rune(s[0])) {
+		s = "X" + s
+	}
+	return s
+}
+
+// goType maps SQL type to Go type and import it requires.
+func goType(c Column) (string, string) {
+	if c.GoType != "" {
+		return c.GoType, c.GoImport
+	}
+
+	typ := strings.ToLower(strings.TrimSpace(c.Type))
+	if strings.HasSuffix(typ, "[]") || strings.HasPrefix(typ, "array") {
+		return "interface{}", ""
+	}
+	base := typ
+	if i := strings.IndexAny(base, " ("); i >= 0 {
+		base = base[:i]
+	}
+
+	var t, imp string
+	switch base {
+	case "tinyint":
+		t = "int8"
+		if strings.HasPrefix(typ, "tinyint(1)") {
+			t = "bool"
+		}
+	case "smallint", "int2", "smallserial", "serial2":
+		t = "int16"
+	case "int", "integer", "int4", "mediumint", "serial", "serial4":
+		t = "int32"
+	case "bigint", "int8", "bigserial", "serial8":
+		t = "int64"
+	case "bool", "boolean", "bit":
+		t = "bool"
+	case "real", "float4":
+		t = "float32"
+	case "double", "float", "float8":
+		t = "float64"
+	case "numeric", "decimal", "money":
+		t = "string"
+	case "text", "varchar", "char", "character", "nvarchar", "nchar", "citext", "uuid", "enum",
+		"tinytext", "mediumtext", "longtext", "inet", "cidr", "macaddr", "interval", "set":
+		t = "string"
+	case "bytea", "blob", "tinyblob", "mediumblob", "longblob", "binary", "varbinary", "json", "jsonb":
+		return "[]byte", ""
+	case "date", "time", "timetz", "timestamp", "timestamptz", "datetime", "year":
+		t, imp = "time.Time", "time"
+		if base == "year" {
+			t, imp = "int16", ""
+		}
+	default:
+		return "interface{}", ""
+	}
+
+	if c.Nullable {
+		t = "*" + t
+	}
+	return t, imp
+}
+
+type genColumn struct {
+	Column
+	GoName string
+	GoType string
+	Tag    string
+}
+
+type genTable struct {
+	Name    string
+	GoName  string
+	Columns []genColumn
+}
+
+type genFile struct {
+	Package string
+	Imports []string
+	Tables  []genTable
+}
+
+// reserved are names of generated table methods columns must not shadow.
+var reserved = map[string]bool{
+	"As": true, "All": true, "WriteSQLTo": true, "IsTable": true, "IsJoinable": true,
+}
+
+// tableDecls are suffixes of package level names declared for table,
+// column constants are named by table and column, so columns must not take them.
+var tableDecls = []string{"", "TableName", "Table", "Row"}
+
+// declare records package level name, declaring it twice is error.
+func declare(decls map[string]string, name, origin string) error {
+	if prev, ok := decls[name]; ok {
+		return fmt.Errorf("%s and %s both declare %s", prev, origin, name)
+	}
+	decls[name] = origin
+	return nil
+}
+
+func generate(pkg string, schema Schema) ([]byte, error) {
+	f := genFile{Package: pkg}
+	imports := map[string]bool{}
+	names := map[string]string{}
+	decls := map[string]string{}
+	for _, t := range schema.Tables {
+		short := t.Name
+		if i := strings.LastIndexByte(short, '.'); i >= 0 {
+			short = short[i+1:]
+		}
+		gt := genTable{Name: t.Name, GoName: goName(short)}
+		if prev, ok := names[gt.GoName]; ok {
+			return nil, fmt.Errorf("tables %s and %s have same Go name %s", prev, t.Name, gt.GoName)
+		}
+		names[gt.GoName] = t.Name
+		for _, suffix := range tableDecls {
+			err := declare(decls, gt.GoName+suffix, "table "+t.Name)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		columns := map[string]string{}
+		for _, c := range t.Columns {
+			gc := genColumn{Column: c, GoName: goName(c.Name)}
+			if reserved[gc.GoName] || decls[gt.GoName+gc.GoName] == "table "+t.Name {
+				gc.GoName += "Col"
+			}
+			if prev, ok := columns[gc.GoName]; ok {
+				return nil, fmt.Errorf("table %s: columns %s and %s have same Go name %s", t.Name, prev, c.Name, gc.GoName)
+			}
+			columns[gc.GoName] = c.Name
+			err := declare(decls, gt.GoName+gc.GoName, "column "+t.Name+"."+c.Name)
+			if err != nil {
+				return nil, err
+			}
+
+			var imp string
+			gc.GoType, imp = goType(c)
+			if imp != "" {
+				imports[imp] = true
+			}
+			gc.Tag = tag(c)
+			gt.Columns = append(gt.Columns, gc)
+		}
+		f.Tables = append(f.Tables, gt)
+	}
+
+	for imp := range imports {
+		f.Imports = append(f.Imports, imp)
+	}
+	sort.Strings(f.Imports)
+
+	var buf bytes.Buffer
+	err := fileTemplate.Execute(&buf, f)
+	if err != nil {
+		return nil, err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated code: %v", err)
+	}
+	return src, nil
+}
+
+func tag(c Column) string {
+	opts := []string{c.Name}
+	if c.PrimaryKey {
+		opts = append(opts, "pk")
+	}
+	if c.Generated {
+		opts = append(opts, "readonly")
+	} else if c.Default {
+		opts = append(opts, "default")
+	}
+	return fmt.Sprintf("`db:%q`", strings.Join(opts, ","))
+}
+
+var fileTemplate = template.Must(template.New("file").Parse(`// Code generated by sqbgen. DO NOT EDIT.
+
+package {{.Package}}
+
+import (
+{{- range .Imports}}
+	"{{.}}"
+{{- end}}
+{{- if .Imports}}
+{{end}}
+	"github.com/vagruchi/sqb"
+)
+{{range $t := .Tables}}
+const {{$t.GoName}}TableName sqb.TableIdentifier = "{{$t.Name}}"
+
+const (
+{{- range $t.Columns}}
+	{{$t.GoName}}{{.GoName}} sqb.Column = "{{.Name}}"
+{{- end}}
+)
+
+// {{$t.GoName}}Table is {{$t.Name}} table, columns are qualified by alias if it is set.
+type {{$t.GoName}}Table struct {
+	alias string
+{{- range $t.Columns}}
+	{{.GoName}} sqb.Column
+{{- end}}
+}
+
+var {{$t.GoName}} = new{{$t.GoName}}Table("")
+
+func new{{$t.GoName}}Table(alias string) {{$t.GoName}}Table {
+	prefix := sqb.Column("")
+	if alias != "" {
+		prefix = sqb.Column(alias + ".")
+	}
+	return {{$t.GoName}}Table{
+		alias: alias,
+{{- range $t.Columns}}
+		{{.GoName}}: prefix + {{$t.GoName}}{{.GoName}},
+{{- end}}
+	}
+}
+
+// As returns table aliased as alias.
+func (t {{$t.GoName}}Table) As(alias string) {{$t.GoName}}Table {
+	return new{{$t.GoName}}Table(alias)
+}
+
+// All returns all columns of table.
+func (t {{$t.GoName}}Table) All() sqb.ColumnList {
+	return sqb.NewColumnList({{range $i, $c := $t.Columns}}{{if $i}}, {{end}}t.{{$c.GoName}}{{end}})
+}
+
+func (t {{$t.GoName}}Table) WriteSQLTo(w sqb.SQLWriter) error {
+	if t.alias == "" {
+		return {{$t.GoName}}TableName.WriteSQLTo(w)
+	}
+	return {{$t.GoName}}TableName.As(t.alias).WriteSQLTo(w)
+}
+
+func ({{$t.GoName}}Table) IsTable()    {}
+func ({{$t.GoName}}Table) IsJoinable() {}
+
+// {{$t.GoName}}Row is row of {{$t.Name}} table.
+type {{$t.GoName}}Row struct {
+{{- range $t.Columns}}
+	{{.GoName}} {{.GoType}} {{.Tag}}
+{{- end}}
+}
+{{end}}`))
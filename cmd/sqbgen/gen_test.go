@@ -0,0 +1,129 @@
+package main
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	gotoken "go/token"
+	"go/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoName(t *testing.T) {
+	assert.Equal(t, "UserID", goName("user_id"))
+	assert.Equal(t, "AvatarURL", goName("avatar_url"))
+	assert.Equal(t, "X2fa", goName("2fa"))
+	assert.Equal(t, "CreatedAt", goName("created-at"))
+}
+
+func TestGenerate(t *testing.T) {
+	schema := Schema{Tables: []Table{{
+		Name: "users",
+		Columns: []Column{
+			{Name: "id", Type: "bigserial", PrimaryKey: true, Default: true},
+			{Name: "email", Type: "text", Nullable: true},
+			{Name: "created_at", Type: "timestamptz", Default: true},
+			{Name: "all", Type: "bool"},
+			{Name: "meta", Type: "jsonb", GoType: "json.RawMessage", GoImport: "encoding/json"},
+		},
+	}}}
+
+	src, err := generate("schema", schema)
+	assert.NoError(t, err)
+	code := string(src)
+	for _, expected := range []string{
+		"package schema",
+		"\"encoding/json\"\n\t\"time\"\n\n\t\"github.com/vagruchi/sqb\"",
+		`UsersTableName sqb.TableIdentifier = "users"`,
+		`UsersEmail     sqb.Column = "email"`,
+		"func (t UsersTable) As(alias string) UsersTable {",
+		"return sqb.NewColumnList(t.ID, t.Email, t.CreatedAt, t.AllCol, t.Meta)",
+		"ID        int64           `db:\"id,pk,default\"`",
+		"Email     *string         `db:\"email\"`",
+		"CreatedAt time.Time       `db:\"created_at,default\"`",
+		"Meta      json.RawMessage `db:\"meta\"`",
+		"func (UsersTable) IsJoinable() {}",
+	} {
+		assert.Contains(t, code, expected)
+	}
+
+	schema.Tables = append(schema.Tables, Table{Name: "other.users"})
+	_, err = generate("schema", schema)
+	assert.Error(t, err)
+}
+
+func TestGenerate_Collisions(t *testing.T) {
+	schema := Schema{Tables: []Table{{
+		Name: "users",
+		Columns: []Column{
+			{Name: "id", Type: "bigserial"},
+			{Name: "row", Type: "int"},
+			{Name: "table_name", Type: "text"},
+			{Name: "table", Type: "text"},
+		},
+	}}}
+
+	src, err := generate("schema", schema)
+	assert.NoError(t, err)
+	code := string(src)
+	for _, expected := range []string{
+		`UsersRowCol       sqb.Column = "row"`,
+		`UsersTableNameCol sqb.Column = "table_name"`,
+		`UsersTableCol     sqb.Column = "table"`,
+		"type UsersRow struct {",
+	} {
+		assert.Contains(t, code, expected)
+	}
+	assertTypeChecks(t, src)
+
+	schema.Tables = append(schema.Tables, Table{Name: "users_row", Columns: []Column{{Name: "id", Type: "int"}}})
+	_, err = generate("schema", schema)
+	assert.EqualError(t, err, "table users and table users_row both declare UsersRow")
+}
+
+func TestGenerate_Compiles(t *testing.T) {
+	schema := Schema{Tables: []Table{
+		{
+			Name: "users",
+			Columns: []Column{
+				{Name: "id", Type: "bigserial", PrimaryKey: true, Default: true},
+				{Name: "email", Type: "text", Nullable: true},
+				{Name: "created_at", Type: "timestamptz", Default: true},
+				{Name: "all", Type: "bool"},
+				{Name: "meta", Type: "jsonb", GoType: "json.RawMessage", GoImport: "encoding/json"},
+			},
+		},
+		{
+			Name: "public.posts",
+			Columns: []Column{
+				{Name: "id", Type: "int", PrimaryKey: true},
+				{Name: "user_id", Type: "bigint"},
+				{Name: "tags", Type: "text[]"},
+			},
+		},
+	}}
+
+	src, err := generate("schema", schema)
+	if assert.NoError(t, err) {
+		assertTypeChecks(t, src)
+	}
+}
+
+// assertTypeChecks type checks generated file with sqb imported from source.
+func assertTypeChecks(t *testing.T, src []byte) {
+	t.Helper()
+	if testing.Short() {
+		return
+	}
+
+	fset := gotoken.NewFileSet()
+	f, err := parser.ParseFile(fset, "schema.go", src, 0)
+	if !assert.NoError(t, err) {
+		return
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	_, err = conf.Check("schema", fset, []*ast.File{f}, nil)
+	assert.NoError(t, err, string(src))
+}
@@ -0,0 +1,51 @@
+// Command sqbgen generates typed table schemas for sqb
+// from CREATE TABLE statements (*.sql) or JSON schema (*.json).
+//
+//	sqbgen -pkg schema -o schema/tables.go schema.sql
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+)
+
+func main() {
+	pkg := flag.String("pkg", "schema", "package name of generated code")
+	out := flag.String("o", "", "output file, stdout if empty")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: sqbgen [flags] schema.sql|schema.json...\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	err := run(*pkg, *out, flag.Args())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "sqbgen:", err)
+		os.Exit(1)
+	}
+}
+
+func run(pkg, out string, files []string) error {
+	schema, err := loadSchema(files)
+	if err != nil {
+		return err
+	}
+
+	src, err := generate(pkg, schema)
+	if err != nil {
+		return err
+	}
+
+	if out == "" {
+		_, err = os.Stdout.Write(src)
+		return err
+	}
+	return ioutil.WriteFile(out, src, 0644)
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+)
+
+// Schema is set of tables code is generated for.
+// It is read from CREATE TABLE statements or from JSON:
+//
+//	{"tables": [{"name": "users", "columns": [
+//		{"name": "id", "type": "bigserial", "primary_key": true},
+//		{"name": "email", "type": "text", "nullable": true}
+//	]}]}
+type Schema struct {
+	Tables []Table `json:"tables"`
+}
+
+type Table struct {
+	Name    string   `json:"name"`
+	Columns []Column `json:"columns"`
+}
+
+type Column struct {
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	Nullable   bool   `json:"nullable"`
+	PrimaryKey bool   `json:"primary_key"`
+	// Default is set for columns which database fills when value is omitted.
+	Default bool `json:"default"`
+	// Generated is set for columns database computes, they are never written.
+	Generated bool `json:"generated"`
+	// GoType overrides Go type derived from Type,
+	// GoImport is package it requires.
+	GoType   string `json:"go_type"`
+	GoImport string `json:"go_import"`
+}
+
+func loadSchema(files []string) (Schema, error) {
+	var schema Schema
+	for _, f := range files {
+		data, err := ioutil.ReadFile(f)
+		if err != nil {
+			return Schema{}, err
+		}
+
+		var part Schema
+		switch strings.ToLower(filepath.Ext(f)) {
+		case ".json":
+			err = json.Unmarshal(data, &part)
+		default:
+			part, err = parseDDL(string(data))
+		}
+		if err != nil {
+			return Schema{}, fmt.Errorf("%s: %v", f, err)
+		}
+		schema.Tables = append(schema.Tables, part.Tables...)
+	}
+	return schema, nil
+}
@@ -0,0 +1,403 @@
+package main
+
+import (
+	"fmt"
+	"go/format"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/vagruchi/sqb"
+)
+
+// argRef is value of n-th placeholder, it is generated as identifier argN.
+type argRef int
+
+var numberedPlaceholder = regexp.MustCompile(`[$?](\d+)`)
+
+// placeholders returns upper bound of number of arguments referenced by sql.
+func placeholders(sql string) int {
+	n := strings.Count(sql, "?")
+	for _, m := range numberedPlaceholder.FindAllStringSubmatch(sql, -1) {
+		i, err := strconv.Atoi(m[1])
+		if err == nil && i > n {
+			n = i
+		}
+	}
+	return n
+}
+
+// generate parses sql and returns formatted declaration of variable name
+// holding equivalent sqb statement.
+func generate(name, sql string) ([]byte, error) {
+	args := make([]interface{}, placeholders(sql))
+	for i := range args {
+		args[i] = argRef(i + 1)
+	}
+
+	s, err := sqb.Parse(sql, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	expr, err := goExpr(s)
+	if err != nil {
+		return nil, err
+	}
+
+	const header = "package p\n\n"
+	src, err := format.Source([]byte(header + "var " + name + " = " + expr + "\n"))
+	if err != nil {
+		return nil, fmt.Errorf("format generated code: %w", err)
+	}
+	return src[len(header):], nil
+}
+
+func goExpr(node sqb.SQB) (string, error) {
+	var g generator
+	s := g.expr(node)
+	return s, g.err
+}
+
+// generator writes Go expressions building nodes,
+// first unsupported node is kept in err.
+type generator struct {
+	err error
+}
+
+func (g *generator) expr(node sqb.SQB) string {
+	switch n := node.(type) {
+	case nil:
+		return "nil"
+	case sqb.SelectStmt:
+		return g.selectStmt(n)
+	case sqb.InsertStmt:
+		return g.insertStmt(n)
+	case sqb.UpdateStmt:
+		return g.updateStmt(n)
+	case sqb.DeleteStmt:
+		return g.deleteStmt(n)
+	case sqb.TableIdentifier:
+		return "sqb.TableName(" + strconv.Quote(string(n)) + ")"
+	case sqb.TableIdentifierAlias:
+		return g.expr(n.TableIdentifier) + ".As(" + strconv.Quote(n.AS) + ")"
+	case sqb.SubqueryAlias:
+		return g.expr(n.SelectStmt) + ".\n\tAs(" + strconv.Quote(n.AS) + ")"
+	case sqb.ValuesTable:
+		args := []string{strconv.Quote(n.AS)}
+		for _, c := range n.Columns {
+			args = append(args, strconv.Quote(string(c)))
+		}
+		return g.expr(n.Values) + ".As(" + strings.Join(args, ", ") + ")"
+	case sqb.JoinBuilder:
+		return g.expr(n.Joinable)
+	case sqb.InnerJoinStmt, sqb.LeftJoinStmt, sqb.RightJoinStmt, sqb.FullOuterJoinStmt, sqb.CrossJoinStmt:
+		return g.joins(n.(sqb.Joinable))
+	case sqb.InsertValuesStmt:
+		rows := make([]string, 0, len(n))
+		for _, row := range n {
+			values := make([]string, 0, len(row))
+			for _, v := range row {
+				values = append(values, g.expr(v))
+			}
+			rows = append(rows, "{"+strings.Join(values, ", ")+"}")
+		}
+		return "sqb.InsertValuesStmt{" + list(rows) + "}"
+	case sqb.EqExpr:
+		return "sqb.Eq(" + g.expr(n.A) + ", " + g.expr(n.B) + ")"
+	case sqb.BinaryOperator:
+		return "sqb.BinaryOp(" + g.expr(n.Left) + ", " + strconv.Quote(n.Op) + ", " + g.expr(n.Right) + ")"
+	case sqb.OrExpr:
+		return "sqb.Or(" + list(g.exprs(n.Exprs)) + ")"
+	case sqb.AndExpr:
+		return "sqb.And(" + list(g.exprs(n.Exprs)) + ")"
+	case sqb.NotExpr:
+		return "sqb.Not(" + g.expr(n.Expr()) + ")"
+	case sqb.OnBoolExpr:
+		return "sqb.OnBool(" + g.expr(n.Expr) + ")"
+	case sqb.InExpr:
+		items := []string{g.expr(n.A)}
+		for _, c := range n.List {
+			items = append(items, g.expr(c))
+		}
+		return "sqb.In(" + list(items) + ")"
+	case sqb.NullCheck:
+		if n.IsNull {
+			return "sqb.NullCheck{A: " + g.expr(n.A) + ", IsNull: true}"
+		}
+		return "sqb.NullCheck{A: " + g.expr(n.A) + "}"
+	case sqb.ExistsStmt:
+		return "sqb.ExistsStmt{Select: " + g.expr(n.Select) + "}"
+	case sqb.Column:
+		return "sqb.Column(" + strconv.Quote(string(n)) + ")"
+	case sqb.ColumnAlias:
+		if c, ok := n.C.(sqb.Column); ok {
+			return g.expr(c) + ".As(" + strconv.Quote(n.AS) + ")"
+		}
+		return "sqb.ColumnAlias{C: " + g.expr(n.C) + ", AS: " + strconv.Quote(n.AS) + "}"
+	case sqb.Arg:
+		if ref, ok := n.V.(argRef); ok {
+			return "sqb.Arg{V: arg" + strconv.Itoa(int(ref)) + "}"
+		}
+		return "sqb.Arg{V: nil}"
+	case sqb.NamedArg:
+		if ref, ok := n.V.(argRef); ok {
+			return "sqb.Named(" + strconv.Quote(n.Name) + ", arg" + strconv.Itoa(int(ref)) + ")"
+		}
+		return "sqb.Named(" + strconv.Quote(n.Name) + ", nil)"
+	case sqb.RawSQL:
+		return "sqb.Raw(" + strconv.Quote(n.Query) + ")"
+	case sqb.CastExpr:
+		return "sqb.Cast(" + g.expr(n.A) + ", " + strconv.Quote(n.Type) + ")"
+	case sqb.CaseExpr:
+		return g.caseExpr(n)
+	case sqb.AggrFuncCall:
+		return g.aggregate(n)
+	}
+
+	if node == sqb.Default {
+		return "sqb.Default"
+	}
+	if g.err == nil {
+		g.err = fmt.Errorf("unsupported node %T", node)
+	}
+	return "nil"
+}
+
+func (g *generator) exprs(nodes []sqb.BoolExpr) []string {
+	s := make([]string, 0, len(nodes))
+	for _, n := range nodes {
+		s = append(s, g.expr(n))
+	}
+	return s
+}
+
+func (g *generator) cols(cols []sqb.Col) []string {
+	s := make([]string, 0, len(cols))
+	for _, c := range cols {
+		s = append(s, g.expr(c))
+	}
+	return s
+}
+
+// list joins arguments, long lists get argument per line.
+func list(items []string) string {
+	s := strings.Join(items, ", ")
+	if len(s) <= 80 && !strings.Contains(s, "\n") {
+		return s
+	}
+	return "\n" + strings.Join(items, ",\n") + ",\n"
+}
+
+func (g *generator) selectStmt(s sqb.SelectStmt) string {
+	var sb strings.Builder
+	sb.WriteString("sqb.From(" + g.expr(s.From) + ")")
+	chain := func(method, args string) {
+		sb.WriteString(".\n\t" + method + "(" + args + ")")
+	}
+
+	if s.IsDistinct {
+		chain("Distinct", "")
+	}
+	if cl, ok := s.Cols.(sqb.ColumnList); ok && len(cl.Cols) > 0 {
+		chain("Select", list(g.cols(cl.Cols)))
+	}
+	if !s.WhereStmt.Empty() {
+		chain("Where", list(g.exprs(s.WhereStmt.Exprs)))
+	}
+	if !s.GroupByStmt.Empty() {
+		chain("GroupBy", list(g.cols(s.GroupByStmt.Cols)))
+	}
+	if !s.OrderByStmt.Empty() {
+		elems := make([]string, 0, len(s.OrderByStmt.Elems))
+		for _, e := range s.OrderByStmt.Elems {
+			f := "sqb.Asc"
+			if e.Kind == sqb.DescOrder {
+				f = "sqb.Desc"
+			}
+			elems = append(elems, f+"("+g.expr(e.C)+")")
+		}
+		chain("OrderBy", list(elems))
+	}
+	if !s.LimitStmt.Empty() {
+		chain("Limit", strconv.FormatUint(s.LimitStmt.V, 10))
+	}
+	if !s.OffsetStmt.Empty() {
+		chain("Offset", strconv.FormatUint(s.OffsetStmt.V, 10))
+	}
+	if s.IsForUpdate {
+		chain("ForUpdate", "")
+	}
+	return sb.String()
+}
+
+// joins generates left-deep join tree as JoinBuilder chain.
+func (g *generator) joins(j sqb.Joinable) string {
+	var steps []string
+	for {
+		var method string
+		var left, right sqb.Joinable
+		var on sqb.OnExpr
+		switch n := j.(type) {
+		case sqb.InnerJoinStmt:
+			method, left, right, on = "InnerJoin", n.LeftTable, n.RightTable, n.On()
+		case sqb.LeftJoinStmt:
+			method, left, right, on = "LeftJoin", n.LeftTable, n.RightTable, n.On()
+		case sqb.RightJoinStmt:
+			method, left, right, on = "RightJoin", n.LeftTable, n.RightTable, n.On()
+		case sqb.FullOuterJoinStmt:
+			method, left, right, on = "FullOuterJoin", n.LeftTable, n.RightTable, n.On()
+		case sqb.CrossJoinStmt:
+			method, left, right = "CrossJoin", n.LeftTable, n.RightTable
+		case sqb.JoinBuilder:
+			j = n.Joinable
+			continue
+		default:
+			var sb strings.Builder
+			sb.WriteString("sqb.JB(" + g.expr(j) + ")")
+			for i := len(steps) - 1; i >= 0; i-- {
+				sb.WriteString(".\n\t" + steps[i])
+			}
+			return sb.String()
+		}
+		steps = append(steps, method+"("+g.expr(right)+", "+g.expr(on)+")")
+		j = left
+	}
+}
+
+func (g *generator) insertStmt(is sqb.InsertStmt) string {
+	var sb strings.Builder
+	sb.WriteString("sqb.Insert(" + g.expr(is.Table) + ", " + columns(is.Columns) + ", " + g.expr(is.Source) + ")")
+
+	switch is.Modifier {
+	case "":
+	case sqb.InsertIgnore:
+		sb.WriteString(".\n\tIgnore()")
+	case sqb.InsertReplace:
+		sb.WriteString(".\n\tReplace()")
+	default:
+		if g.err == nil {
+			g.err = fmt.Errorf("unsupported insert modifier %q", is.Modifier)
+		}
+	}
+
+	oc := is.OnConflictStmt
+	switch {
+	case oc.DoNothing:
+		names := make([]string, 0, len(oc.Target))
+		for _, c := range oc.Target {
+			names = append(names, strconv.Quote(string(c)))
+		}
+		sb.WriteString(".\n\tOnConflictDoNothing(" + strings.Join(names, ", ") + ")")
+	case !oc.Empty():
+		args := []string{columns(oc.Target), g.set(oc.Set)}
+		args = append(args, g.exprs(oc.WhereStmt.Exprs)...)
+		sb.WriteString(".\n\tOnConflictDoUpdate(" + list(args) + ")")
+	}
+	return sb.String() + g.returning(is.ReturningStmt)
+}
+
+// columns generates slice of column names, nil for empty one.
+func columns(cols []sqb.Column) string {
+	if len(cols) == 0 {
+		return "nil"
+	}
+	names := make([]string, 0, len(cols))
+	for _, c := range cols {
+		names = append(names, strconv.Quote(string(c)))
+	}
+	return "[]sqb.Column{" + strings.Join(names, ", ") + "}"
+}
+
+func (g *generator) set(set sqb.SetStmt) string {
+	sets := make([]string, 0, len(set))
+	for _, sa := range set {
+		sets = append(sets, "{Key: "+strconv.Quote(string(sa.Key))+", Value: "+g.expr(sa.Value)+"}")
+	}
+	return "sqb.SetStmt{" + list(sets) + "}"
+}
+
+func (g *generator) returning(rs sqb.ReturningStmt) string {
+	if rs.Cols == nil {
+		return ""
+	}
+	cl, _ := rs.Cols.(sqb.ColumnList)
+	return ".\n\tReturning(" + list(g.cols(cl.Cols)) + ")"
+}
+
+func (g *generator) updateStmt(us sqb.UpdateStmt) string {
+	var sb strings.Builder
+	sb.WriteString("sqb.UpdateStmt{\n")
+	sb.WriteString("Table: " + g.expr(us.Table) + ",\n")
+
+	sb.WriteString("Set: " + g.set(us.Set) + ",\n")
+
+	if !us.From.Empty() {
+		sb.WriteString("From: sqb.UpdateFromStmt{Table: " + g.expr(us.From.Table))
+		if us.From.On != nil {
+			sb.WriteString(", On: " + g.expr(us.From.On))
+		}
+		sb.WriteString("},\n")
+	}
+	if !us.WhereStmt.Empty() {
+		sb.WriteString("WhereStmt: sqb.WhereStmt{Exprs: []sqb.BoolExpr{" + list(g.exprs(us.WhereStmt.Exprs)) + "}},\n")
+	}
+	sb.WriteString("}")
+	return sb.String() + g.returning(us.ReturningStmt)
+}
+
+func (g *generator) deleteStmt(ds sqb.DeleteStmt) string {
+	s := "sqb.Delete(" + g.expr(ds.Table) + ")"
+	if !ds.WhereStmt.Empty() {
+		s += ".\n\tWhere(" + list(g.exprs(ds.WhereStmt.Exprs)) + ")"
+	}
+	return s + g.returning(ds.ReturningStmt)
+}
+
+func (g *generator) caseExpr(ce sqb.CaseExpr) string {
+	var fields []string
+	if ce.Operand != nil {
+		fields = append(fields, "Operand: "+g.expr(ce.Operand))
+	}
+	whens := make([]string, 0, len(ce.Whens))
+	for _, w := range ce.Whens {
+		whens = append(whens, "{When: "+g.expr(w.When)+", Then: "+g.expr(w.Then)+"}")
+	}
+	fields = append(fields, "Whens: []sqb.WhenClause{"+list(whens)+"}")
+	if ce.Else != nil {
+		fields = append(fields, "Else: "+g.expr(ce.Else))
+	}
+	return "sqb.CaseExpr{" + list(fields) + "}"
+}
+
+var aggregates = map[string]string{"COUNT": "Count", "MAX": "Max", "MIN": "Min", "SUM": "Sum", "AVG": "Avg"}
+
+func (g *generator) aggregate(fc sqb.AggrFuncCall) string {
+	args := make([]string, 0, len(fc.Args))
+	for _, a := range fc.Args {
+		args = append(args, g.expr(a))
+	}
+
+	f, ok := aggregates[strings.ToUpper(fc.Name)]
+	for _, a := range fc.Args {
+		if _, isCol := a.(sqb.Col); !isCol {
+			ok = false
+		}
+	}
+	if !ok {
+		s := "sqb.AggrFuncCall{Name: " + strconv.Quote(fc.Name)
+		if len(args) > 0 {
+			s += ", Args: []sqb.SQB{" + strings.Join(args, ", ") + "}"
+		}
+		if fc.IsDistinct {
+			s += ", IsDistinct: true"
+		}
+		return s + "}"
+	}
+
+	s := "sqb." + f + "(" + strings.Join(args, ", ") + ")"
+	if fc.IsDistinct {
+		s += ".Distinct()"
+	}
+	return s
+}
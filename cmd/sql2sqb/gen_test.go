@@ -0,0 +1,132 @@
+package main
+
+import (
+	"bytes"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var statements = []string{
+	"SELECT u.id, count(*) AS n FROM users u LEFT JOIN posts p ON p.user_id = u.id AND p.draft = FALSE WHERE u.name LIKE $1 AND u.id IN ($2, $1) GROUP BY u.id ORDER BY n DESC LIMIT 10",
+	"SELECT DISTINCT * FROM (SELECT id FROM users WHERE NOT (deleted_at IS NOT NULL)) AS u CROSS JOIN cities WHERE EXISTS (SELECT * FROM bans WHERE bans.user_id = u.id) OFFSET 5",
+	"SELECT CAST(id AS text), sum(DISTINCT total) FROM (VALUES (?, ?)) AS v(id, total) FOR UPDATE",
+	"INSERT INTO users (id, name) VALUES (?, ?), (?, DEFAULT) RETURNING id",
+	"INSERT INTO archive SELECT * FROM users",
+	"UPDATE users SET name = ?, visits = CASE id WHEN 1 THEN 2 ELSE visits END FROM cities c WHERE c.id = users.city_id AND (a = 1 OR b = 2)",
+	"UPDATE users INNER JOIN cities ON cities.id = users.city_id SET users.city = cities.name",
+	"DELETE FROM sessions WHERE expires_at < $1 RETURNING id",
+	"SELECT * FROM users WHERE (id=?2) AND ((owner_id=?1) OR (editor_id=?1))",
+	"INSERT IGNORE INTO users (id) VALUES (?)",
+	"REPLACE INTO users (id, name) VALUES (?, ?)",
+	"INSERT INTO users (id) VALUES ($1) ON CONFLICT DO NOTHING",
+	"INSERT INTO users (id, name) VALUES ($1, $2) ON CONFLICT (id) DO UPDATE SET name = excluded.name WHERE users.locked = FALSE RETURNING id",
+}
+
+func TestGenerate(t *testing.T) {
+	src, err := generate("usersQuery", statements[0])
+	assert.NoError(t, err)
+	assert.Equal(t, `var usersQuery = sqb.From(sqb.JB(sqb.TableName("users").As("u")).
+	LeftJoin(sqb.TableName("posts").As("p"), sqb.OnBool(sqb.And(
+		sqb.Eq(sqb.Column("p.user_id"), sqb.Column("u.id")),
+		sqb.Eq(sqb.Column("p.draft"), sqb.Raw("FALSE")),
+	)))).
+	Select(sqb.Column("u.id"), sqb.ColumnAlias{C: sqb.Count(sqb.Column("*")), AS: "n"}).
+	Where(
+		sqb.BinaryOp(sqb.Column("u.name"), "LIKE", sqb.Named("p1", arg1)),
+		sqb.In(sqb.Column("u.id"), sqb.Arg{V: arg2}, sqb.Named("p1", arg1)),
+	).
+	GroupBy(sqb.Column("u.id")).
+	OrderBy(sqb.Desc(sqb.Column("n"))).
+	Limit(10)
+`, string(src))
+
+	_, err = generate("q", "TRUNCATE users")
+	assert.Error(t, err)
+}
+
+func TestGenerate_Insert(t *testing.T) {
+	tests := []struct {
+		name     string
+		sql      string
+		expected string
+	}{
+		{
+			name:     "ignore",
+			sql:      "INSERT IGNORE INTO users (id) VALUES (?)",
+			expected: "var q = sqb.Insert(sqb.TableName(\"users\"), []sqb.Column{\"id\"}, sqb.InsertValuesStmt{{sqb.Arg{V: arg1}}}).\n\tIgnore()\n",
+		},
+		{
+			name:     "replace",
+			sql:      "REPLACE INTO users (id) VALUES (?)",
+			expected: "var q = sqb.Insert(sqb.TableName(\"users\"), []sqb.Column{\"id\"}, sqb.InsertValuesStmt{{sqb.Arg{V: arg1}}}).\n\tReplace()\n",
+		},
+		{
+			name:     "sqlite or ignore",
+			sql:      "INSERT OR IGNORE INTO users (id) VALUES (?)",
+			expected: "var q = sqb.Insert(sqb.TableName(\"users\"), []sqb.Column{\"id\"}, sqb.InsertValuesStmt{{sqb.Arg{V: arg1}}}).\n\tIgnore()\n",
+		},
+		{
+			name:     "on conflict do nothing",
+			sql:      "INSERT INTO users (id) VALUES ($1) ON CONFLICT (id) DO NOTHING",
+			expected: "var q = sqb.Insert(sqb.TableName(\"users\"), []sqb.Column{\"id\"}, sqb.InsertValuesStmt{{sqb.Arg{V: arg1}}}).\n\tOnConflictDoNothing(\"id\")\n",
+		},
+		{
+			name: "on conflict do update",
+			sql:  "INSERT INTO users (id, name) VALUES ($1, $2) ON CONFLICT (id) DO UPDATE SET name = excluded.name WHERE users.locked = FALSE RETURNING id",
+			expected: `var q = sqb.Insert(sqb.TableName("users"), []sqb.Column{"id", "name"}, sqb.InsertValuesStmt{{sqb.Arg{V: arg1}, sqb.Arg{V: arg2}}}).
+	OnConflictDoUpdate(
+		[]sqb.Column{"id"},
+		sqb.SetStmt{{Key: "name", Value: sqb.Column("excluded.name")}},
+		sqb.Eq(sqb.Column("users.locked"), sqb.Raw("FALSE")),
+	).
+	Returning(sqb.Column("id"))
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src, err := generate("q", tt.sql)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, string(src))
+		})
+	}
+}
+
+func TestGenerate_Compiles(t *testing.T) {
+	if testing.Short() {
+		t.Skip("type checks package from source")
+	}
+
+	var sb strings.Builder
+	sb.WriteString("package p\n\nimport \"github.com/vagruchi/sqb\"\n\nvar arg1, arg2, arg3, arg4 interface{}\n\n")
+	for i, sql := range statements {
+		src, err := generate("q"+strings.Repeat("_", i), sql)
+		if !assert.NoError(t, err, sql) {
+			return
+		}
+		sb.Write(src)
+	}
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "p.go", sb.String(), 0)
+	if !assert.NoError(t, err) {
+		return
+	}
+	conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	_, err = conf.Check("p", fset, []*ast.File{f}, nil)
+	assert.NoError(t, err, sb.String())
+}
+
+func TestRun(t *testing.T) {
+	var out bytes.Buffer
+	err := run("q", nil, strings.NewReader("SELECT * FROM users"), &out)
+	assert.NoError(t, err)
+	assert.Equal(t, "var q = sqb.From(sqb.TableName(\"users\"))\n", out.String())
+}
@@ -0,0 +1,48 @@
+// Command sql2sqb prints Go code building sqb statement equivalent to SQL
+// given as argument or read from stdin. Placeholders become variables
+// arg1, arg2, ... in order of their numbers.
+//
+//	sql2sqb -var usersQuery "SELECT id FROM users WHERE name = ?"
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+)
+
+func main() {
+	name := flag.String("var", "query", "name of generated variable")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: sql2sqb [flags] [statement]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	err := run(*name, flag.Args(), os.Stdin, os.Stdout)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "sql2sqb:", err)
+		os.Exit(1)
+	}
+}
+
+func run(name string, args []string, in io.Reader, out io.Writer) error {
+	sql := strings.Join(args, " ")
+	if sql == "" {
+		b, err := ioutil.ReadAll(in)
+		if err != nil {
+			return err
+		}
+		sql = string(b)
+	}
+
+	src, err := generate(name, sql)
+	if err != nil {
+		return err
+	}
+	_, err = out.Write(src)
+	return err
+}
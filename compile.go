@@ -0,0 +1,170 @@
+package sqb
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// paramRef is value of Arg standing for named parameter of compiled statement.
+type paramRef string
+
+// Param is placeholder for value bound later by name, see Compile.
+// Statement with parameters must be rendered with Compile,
+// otherwise parameter itself is passed to database as argument.
+func Param(name string) Arg {
+	return Arg{V: paramRef(name)}
+}
+
+// ParamOf is Param of type T.
+func ParamOf[T any](name string) TypedArg[T] {
+	return TypedArg[T]{Arg: Param(name)}
+}
+
+// Template is statement rendered once, which arguments are bound by parameter names.
+// Template is safe for concurrent use.
+type Template struct {
+	SQL string
+
+	// args holds arguments in placeholders order,
+	// parameter slots have nil value and their name in params at the same index.
+	args   []interface{}
+	params []string
+	names  []string
+
+	fields sync.Map // reflect.Type -> [][]int
+}
+
+// Compile renders s in dialect d into template with slots for parameters made by Param.
+// Arguments which are not parameters are kept as is.
+func Compile(d Dialect, s SQB) (*Template, error) {
+	sql, args, err := d.ToSQL(s)
+	if err != nil {
+		return nil, err
+	}
+	t := &Template{
+		SQL:    sql,
+		args:   args,
+		params: make([]string, len(args)),
+	}
+	seen := make(map[string]bool)
+	for i, a := range args {
+		name, ok := a.(paramRef)
+		if !ok {
+			continue
+		}
+		t.args[i] = nil
+		t.params[i] = string(name)
+		if !seen[string(name)] {
+			seen[string(name)] = true
+			t.names = append(t.names, string(name))
+		}
+	}
+	return t, nil
+}
+
+// Params returns names of parameters in order of their first use.
+func (t *Template) Params() []string {
+	return t.names
+}
+
+// Bind returns arguments of template with parameters taken from params,
+// which is map with string keys or struct, which fields are matched by TagName tags.
+// Missing parameter is error, extra values are ignored.
+func (t *Template) Bind(params interface{}) ([]interface{}, error) {
+	if m, ok := params.(map[string]interface{}); ok {
+		return t.bindMap(m)
+	}
+
+	rv := reflect.ValueOf(params)
+	if rv.Kind() == reflect.Map {
+		if rv.Type().Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("sqb: parameters map key must be string, got %s", rv.Type().Key())
+		}
+		return t.bindMapValue(rv)
+	}
+
+	rv, err := structValue(params)
+	if err != nil {
+		return nil, err
+	}
+	return t.bindStruct(rv)
+}
+
+func (t *Template) bindMap(m map[string]interface{}) ([]interface{}, error) {
+	args := make([]interface{}, len(t.args))
+	for i, a := range t.args {
+		name := t.params[i]
+		if name == "" {
+			args[i] = a
+			continue
+		}
+		v, ok := m[name]
+		if !ok {
+			return nil, missingParam(name)
+		}
+		args[i] = v
+	}
+	return args, nil
+}
+
+func (t *Template) bindMapValue(m reflect.Value) ([]interface{}, error) {
+	args := make([]interface{}, len(t.args))
+	for i, a := range t.args {
+		name := t.params[i]
+		if name == "" {
+			args[i] = a
+			continue
+		}
+		v := m.MapIndex(reflect.ValueOf(name).Convert(m.Type().Key()))
+		if !v.IsValid() {
+			return nil, missingParam(name)
+		}
+		args[i] = v.Interface()
+	}
+	return args, nil
+}
+
+func (t *Template) bindStruct(rv reflect.Value) ([]interface{}, error) {
+	index, err := t.structIndex(rv.Type())
+	if err != nil {
+		return nil, err
+	}
+	args := make([]interface{}, len(t.args))
+	for i, a := range t.args {
+		if index[i] == nil {
+			args[i] = a
+			continue
+		}
+		args[i] = fieldInterface(fieldValue(rv, index[i]))
+	}
+	return args, nil
+}
+
+// structIndex returns field index of every parameter slot of template in struct of type st.
+func (t *Template) structIndex(st reflect.Type) ([][]int, error) {
+	if cached, ok := t.fields.Load(st); ok {
+		return cached.([][]int), nil
+	}
+	byName := make(map[string][]int)
+	for _, f := range structFields(st) {
+		byName[f.name] = f.index
+	}
+	index := make([][]int, len(t.params))
+	for i, name := range t.params {
+		if name == "" {
+			continue
+		}
+		fi, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("sqb: parameter %q has no field in %s", name, st)
+		}
+		index[i] = fi
+	}
+	t.fields.Store(st, index)
+	return index, nil
+}
+
+func missingParam(name string) error {
+	return fmt.Errorf("sqb: missing parameter %q", name)
+}
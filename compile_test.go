@@ -0,0 +1,102 @@
+package sqb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompile(t *testing.T) {
+	users := TableName("users")
+	s := From(users).Where(
+		Eq(Column("id"), Param("id")),
+		Eq(Column("deleted"), Arg{V: false}),
+		Or(Eq(Column("owner_id"), ParamOf[int64]("user_id")), Eq(Column("author_id"), Param("user_id"))),
+	)
+
+	tmpl, err := Compile(PostgreSQLDialect{}, s)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "SELECT * FROM users WHERE (id=$1) AND (deleted=$2) AND ((owner_id=$3) OR (author_id=$4))", tmpl.SQL)
+	assert.Equal(t, []string{"id", "user_id"}, tmpl.Params())
+
+	t.Run("map", func(t *testing.T) {
+		args, err := tmpl.Bind(map[string]interface{}{"id": 1, "user_id": int64(2), "extra": 3})
+		assert.NoError(t, err)
+		assert.Equal(t, []interface{}{1, false, int64(2), int64(2)}, args)
+	})
+
+	t.Run("typed map", func(t *testing.T) {
+		args, err := tmpl.Bind(map[string]int64{"id": 1, "user_id": 2})
+		assert.NoError(t, err)
+		assert.Equal(t, []interface{}{int64(1), false, int64(2), int64(2)}, args)
+	})
+
+	t.Run("struct", func(t *testing.T) {
+		type params struct {
+			ID     int    `db:"id"`
+			UserID int64  `db:"user_id"`
+			Name   string `db:"name"`
+		}
+		for i := 0; i < 2; i++ {
+			args, err := tmpl.Bind(&params{ID: 1, UserID: 2})
+			assert.NoError(t, err)
+			assert.Equal(t, []interface{}{1, false, int64(2), int64(2)}, args)
+		}
+	})
+
+	t.Run("missing", func(t *testing.T) {
+		_, err := tmpl.Bind(map[string]interface{}{"id": 1})
+		assert.EqualError(t, err, `sqb: missing parameter "user_id"`)
+
+		_, err = tmpl.Bind(struct {
+			ID int `db:"id"`
+		}{})
+		assert.EqualError(t, err, `sqb: parameter "user_id" has no field in struct { ID int "db:\"id\"" }`)
+	})
+
+	t.Run("not struct", func(t *testing.T) {
+		_, err := tmpl.Bind(1)
+		assert.EqualError(t, err, "sqb: expected struct, got int")
+	})
+}
+
+func TestCompile_Insert(t *testing.T) {
+	s := Insert(TableName("users"), []Column{"name", "role"}, InsertValuesStmt{{Param("name"), Arg{V: "user"}}})
+	tmpl, err := Compile(MySQLDialect{}, s)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, "INSERT INTO users(name, role) VALUES (?, ?)", tmpl.SQL)
+	args, err := tmpl.Bind(map[string]interface{}{"name": "alice"})
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"alice", "user"}, args)
+}
+
+func BenchmarkTemplate_Bind(b *testing.B) {
+	s := From(TableName("users")).Where(Eq(Column("id"), Param("id")), Eq(Column("role"), Param("role")))
+	tmpl, err := Compile(PostgreSQLDialect{}, s)
+	if err != nil {
+		b.Fatal(err)
+	}
+	type params struct {
+		ID   int64  `db:"id"`
+		Role string `db:"role"`
+	}
+
+	b.Run("map", func(b *testing.B) {
+		m := map[string]interface{}{"id": int64(1), "role": "admin"}
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = tmpl.Bind(m)
+		}
+	})
+	b.Run("struct", func(b *testing.B) {
+		p := &params{ID: 1, Role: "admin"}
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			_, _ = tmpl.Bind(p)
+		}
+	})
+}
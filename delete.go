@@ -0,0 +1,64 @@
+package sqb
+
+type DeleteStmt struct {
+	Table         TableIdentifier
+	WhereStmt     WhereStmt
+	ReturningStmt ReturningStmt
+}
+
+func Delete(table TableIdentifier) DeleteStmt {
+	return DeleteStmt{Table: table}
+}
+
+func (ds DeleteStmt) Where(exprs ...BoolExpr) DeleteStmt {
+	ds.WhereStmt = WhereStmt{Exprs: exprs}
+	return ds
+}
+
+func (ds DeleteStmt) Returning(cc ...Col) DeleteStmt {
+	ds.ReturningStmt.Cols = NewColumnList(cc...)
+	return ds
+}
+
+func (ds DeleteStmt) WriteSQLTo(w SQLWriter) error {
+	err := beginStmt(w)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.WriteString(`DELETE FROM `)
+	if err != nil {
+		return err
+	}
+
+	err = ds.Table.WriteSQLTo(w)
+	if err != nil {
+		return err
+	}
+
+	if !ds.WhereStmt.Empty() {
+		err = writeClause(w)
+		if err != nil {
+			return err
+		}
+
+		err = ds.WhereStmt.WriteSQLTo(w)
+		if err != nil {
+			return err
+		}
+	}
+	// must be last statement
+	if ds.ReturningStmt.Cols != nil {
+		if isMySQL(w) {
+			return unsupported(w, ds, "ReturningStmt", "RETURNING")
+		}
+		if err = sqliteUnsupported(w, ds, "ReturningStmt", "RETURNING", sqliteReturning); err != nil {
+			return err
+		}
+		err = ds.ReturningStmt.WriteSQLTo(w)
+		if err != nil {
+			return err
+		}
+	}
+	return endStmt(w)
+}
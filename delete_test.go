@@ -0,0 +1,60 @@
+package sqb
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteStmt_WriteSQLTo(t *testing.T) {
+	tests := []struct {
+		name           string
+		sqb            SQB
+		dialect        Dialect
+		wantErr        bool
+		expectedRawSQL string
+		expectedArgs   []interface{}
+	}{
+		{
+			name:           "all rows",
+			sqb:            Delete(TableName("sessions")),
+			dialect:        DefaultDialect{},
+			expectedRawSQL: "DELETE FROM sessions",
+		},
+		{
+			name:           "where returning",
+			sqb:            Delete(TableName("sessions")).Where(Eq(Column("user_id"), Arg{V: 1})).Returning(Column("id")),
+			dialect:        PostgreSQLDialect{},
+			expectedRawSQL: "DELETE FROM sessions WHERE (user_id=$1) RETURNING id",
+			expectedArgs:   []interface{}{1},
+		},
+		{
+			name:    "returning on mysql",
+			sqb:     Delete(TableName("sessions")).Returning(Column("id")),
+			dialect: MySQLDialect{},
+			wantErr: true,
+		},
+		{
+			name:    "returning on old sqlite",
+			sqb:     Delete(TableName("sessions")).Returning(Column("id")),
+			dialect: SQLiteDialect{Version: 3034000},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builded, args, err := tt.dialect.ToSQL(tt.sqb)
+			if tt.wantErr {
+				assert.True(t, errors.Is(err, ErrUnsupported))
+				return
+			}
+			assert.NoError(t, err)
+			if builded != tt.expectedRawSQL {
+				t.Errorf("WriteSQLTo() raw SQL expected = %v, actual = %v", tt.expectedRawSQL, builded)
+			}
+			assert.Equal(t, tt.expectedArgs, args)
+			assertRoundTrip(t, tt.dialect, tt.sqb)
+		})
+	}
+}
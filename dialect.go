@@ -1,5 +1,161 @@
 package sqb
 
+import "strings"
+
 type CustomPlaceholder interface {
 	WritePlaceholder() error
 }
+
+// Dialect is SQL flavour of particular database.
+// Statements consult dialect of SQLWriter when syntax differs between databases.
+type Dialect interface {
+	// ToSQL renders statement with SQLWriter of the dialect.
+	ToSQL(SQB) (string, []interface{}, error)
+	// MaxArgs is limit of bind parameters in one statement, zero means no limit.
+	MaxArgs() int
+}
+
+// DialectWriter is SQLWriter aware of its dialect.
+type DialectWriter interface {
+	SQLWriter
+	Dialect() Dialect
+}
+
+// DefaultDialect is generic SQL with ? placeholders.
+type DefaultDialect struct{}
+
+func (DefaultDialect) ToSQL(s SQB) (string, []interface{}, error) {
+	return ToSQL(s)
+}
+
+func (DefaultDialect) MaxArgs() int { return 0 }
+
+type PostgreSQLDialect struct{}
+
+func (PostgreSQLDialect) ToSQL(s SQB) (string, []interface{}, error) {
+	return ToPostgreSql(s)
+}
+
+func (PostgreSQLDialect) MaxArgs() int { return 65535 }
+
+// MySQLDialect is MySQL with ? placeholders.
+// It refuses RETURNING and FULL OUTER JOIN, which MySQL lacks.
+type MySQLDialect struct {
+	// QuoteIdents quotes names of tables, columns and aliases with backticks,
+	// names are split by dots, so Column must not hold expression.
+	QuoteIdents bool
+	// LimitComma renders LIMIT offset, count instead of LIMIT count OFFSET offset.
+	LimitComma bool
+}
+
+func (d MySQLDialect) ToSQL(s SQB) (string, []interface{}, error) {
+	return toSQL(d, s)
+}
+
+func (MySQLDialect) MaxArgs() int { return 65535 }
+
+// queryWriter is DialectWriter which collects rendered Query.
+type queryWriter interface {
+	DialectWriter
+	query() Query
+}
+
+func newWriter(d Dialect) queryWriter {
+	return &Buffer{D: d}
+}
+
+func dialectOf(w SQLWriter) Dialect {
+	if dw, ok := w.(DialectWriter); ok {
+		return dw.Dialect()
+	}
+	return DefaultDialect{}
+}
+
+func isMySQL(w SQLWriter) bool {
+	return isMySQLDialect(dialectOf(w))
+}
+
+func isMySQLDialect(d Dialect) bool {
+	_, ok := d.(MySQLDialect)
+	return ok
+}
+
+// identQuoter is implemented by dialects which may quote identifiers.
+type identQuoter interface {
+	// identQuote returns quote of identifiers or empty string if they are written as is.
+	identQuote() string
+}
+
+func (d MySQLDialect) identQuote() string {
+	if d.QuoteIdents {
+		return "`"
+	}
+	return ""
+}
+
+func identQuoteOf(w SQLWriter) string {
+	if iq, ok := dialectOf(w).(identQuoter); ok {
+		return iq.identQuote()
+	}
+	return ""
+}
+
+// writeIdent writes name qualified by dots, its parts are quoted if dialect quotes identifiers
+// and they are not quoted already.
+func writeIdent(w SQLWriter, name string) error {
+	q := identQuoteOf(w)
+	if q == "" {
+		_, err := w.WriteString(name)
+		return err
+	}
+	for {
+		part, rest, more := strings.Cut(name, ".")
+		var err error
+		if len(part) > 1 && strings.HasPrefix(part, q) && strings.HasSuffix(part, q) {
+			_, err = w.WriteString(part)
+		} else {
+			err = writeQuoted(w, q, part)
+		}
+		if err != nil {
+			return err
+		}
+		if !more {
+			return nil
+		}
+		_, err = w.WriteString(".")
+		if err != nil {
+			return err
+		}
+		name = rest
+	}
+}
+
+// writeAlias writes alias, which is quoted if dialect quotes identifiers or alias is not plain identifier.
+func writeAlias(w SQLWriter, name string) error {
+	q := identQuoteOf(w)
+	if q == "" {
+		if isPlainIdent(name) {
+			_, err := w.WriteString(name)
+			return err
+		}
+		q = `"`
+		if isMySQL(w) {
+			q = "`"
+		}
+	}
+	return writeQuoted(w, q, name)
+}
+
+func writeQuoted(w SQLWriter, q, name string) error {
+	if name == "*" {
+		_, err := w.WriteString(name)
+		return err
+	}
+	return writeStrings(w, q, strings.ReplaceAll(name, q, q+q), q)
+}
+
+// limitComma reports whether LIMIT takes offset before count.
+func limitComma(w SQLWriter) bool {
+	d, ok := dialectOf(w).(MySQLDialect)
+	return ok && d.LimitComma
+}
@@ -0,0 +1,138 @@
+package sqb
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+)
+
+// Sentinel errors matched by errors.Is against typed errors of the package.
+// Errors returned by SQLWriter are passed through unchanged.
+var (
+	ErrUnsupported      = errors.New("sqb: unsupported by dialect")
+	ErrInvalidStatement = errors.New("sqb: invalid statement")
+	ErrNilNode          = errors.New("sqb: nil node")
+)
+
+// UnsupportedError is construct which dialect can not express.
+type UnsupportedError struct {
+	Dialect Dialect
+	// Node is type of node, Clause is its part dialect does not support.
+	Node    string
+	Clause  string
+	Feature string
+}
+
+func (e *UnsupportedError) Error() string {
+	node := e.Node
+	if e.Clause != "" {
+		node += "." + e.Clause
+	}
+	return "sqb: " + dialectName(e.Dialect) + " does not support " + e.Feature + " (" + node + ")"
+}
+
+func (e *UnsupportedError) Is(target error) bool {
+	return target == ErrUnsupported
+}
+
+// InvalidStatementError is node which renders into invalid SQL.
+type InvalidStatementError struct {
+	// Node is type of node, Path is chain of fields from statement to it,
+	// Path is empty when error is found while rendering.
+	Node string
+	Path string
+	Msg  string
+}
+
+func (e *InvalidStatementError) Error() string {
+	return "sqb: " + joinPath(e.Node, e.Path) + ": " + e.Msg
+}
+
+func (e *InvalidStatementError) Is(target error) bool {
+	return target == ErrInvalidStatement
+}
+
+// NilNodeError is required child Field of node which is nil.
+type NilNodeError struct {
+	Node  string
+	Path  string
+	Field string
+}
+
+func (e *NilNodeError) Error() string {
+	return "sqb: " + joinPath(e.Node, e.Path) + ": " + e.Field + " is nil"
+}
+
+func (e *NilNodeError) Is(target error) bool {
+	return target == ErrNilNode || target == ErrInvalidStatement
+}
+
+// ValidationError lists all problems of statement,
+// each of them is *InvalidStatementError or *NilNodeError.
+type ValidationError struct {
+	Problems []error
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, 0, len(e.Problems))
+	for _, p := range e.Problems {
+		msgs = append(msgs, strings.TrimPrefix(p.Error(), "sqb: "))
+	}
+	return "sqb: invalid statement: " + strings.Join(msgs, "; ")
+}
+
+func (e *ValidationError) Is(target error) bool {
+	if target == ErrInvalidStatement {
+		return true
+	}
+	for _, p := range e.Problems {
+		if errors.Is(p, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds first problem matching target.
+func (e *ValidationError) As(target interface{}) bool {
+	for _, p := range e.Problems {
+		if errors.As(p, target) {
+			return true
+		}
+	}
+	return false
+}
+
+func invalid(node SQB, msg string) error {
+	return &InvalidStatementError{Node: nodeName(node), Msg: msg}
+}
+
+func nilNode(node SQB, field string) error {
+	return &NilNodeError{Node: nodeName(node), Field: field}
+}
+
+func unsupported(w SQLWriter, node SQB, clause, feature string) error {
+	return &UnsupportedError{Dialect: dialectOf(w), Node: nodeName(node), Clause: clause, Feature: feature}
+}
+
+func nodeName(node SQB) string {
+	if node == nil {
+		return "<nil>"
+	}
+	return reflect.TypeOf(node).Name()
+}
+
+func dialectName(d Dialect) string {
+	if d == nil {
+		return "dialect"
+	}
+	return strings.TrimSuffix(reflect.TypeOf(d).Name(), "Dialect")
+}
+
+// joinPath returns path if it is known, it starts with node type anyway.
+func joinPath(node, path string) string {
+	if path == "" {
+		return node
+	}
+	return path
+}
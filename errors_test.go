@@ -0,0 +1,94 @@
+package sqb
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type failingWriter struct {
+	DefaultSQLWriter
+	err error
+}
+
+func (fw *failingWriter) WriteString(string) (int, error) {
+	return 0, fw.err
+}
+
+func TestErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		is       []error
+		isNot    []error
+		expected string
+	}{
+		{
+			name:     "unsupported",
+			err:      unsupported(&MySQLWriter{}, InsertStmt{}, "ReturningStmt", "RETURNING"),
+			is:       []error{ErrUnsupported},
+			isNot:    []error{ErrInvalidStatement, ErrNilNode},
+			expected: "sqb: MySQL does not support RETURNING (InsertStmt.ReturningStmt)",
+		},
+		{
+			name:     "invalid",
+			err:      In(Column("id")).WriteSQLTo(&DefaultSQLWriter{}),
+			is:       []error{ErrInvalidStatement},
+			isNot:    []error{ErrUnsupported, ErrNilNode},
+			expected: "sqb: InExpr: IN list must not be empty",
+		},
+		{
+			name:     "nil node",
+			err:      SelectStmt{}.WriteSQLTo(&DefaultSQLWriter{}),
+			is:       []error{ErrNilNode, ErrInvalidStatement},
+			isNot:    []error{ErrUnsupported},
+			expected: "sqb: SelectStmt: From is nil",
+		},
+		{
+			name:     "validation",
+			err:      Validate(From(TableName("users")).Where(Eq(nil, Arg{V: 1})).Offset(1)),
+			is:       []error{ErrNilNode, ErrInvalidStatement},
+			isNot:    []error{ErrUnsupported},
+			expected: "sqb: invalid statement: SelectStmt: OFFSET without LIMIT; SelectStmt.WhereStmt.Exprs[0]: A is nil",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.EqualError(t, tt.err, tt.expected)
+			for _, target := range tt.is {
+				assert.True(t, errors.Is(tt.err, target), "must be %v", target)
+			}
+			for _, target := range tt.isNot {
+				assert.False(t, errors.Is(tt.err, target), "must not be %v", target)
+			}
+		})
+	}
+}
+
+func TestErrors_As(t *testing.T) {
+	err := Validate(UpdateStmt{Table: TableName("users"), Set: SetStmt{{Key: Column("name")}}})
+
+	var nne *NilNodeError
+	if assert.True(t, errors.As(err, &nne)) {
+		assert.Equal(t, &NilNodeError{Node: "SetArg", Path: "UpdateStmt.Set[0]", Field: "Value"}, nne)
+	}
+
+	_, _, err = ToMySQL(From(TableName("users")).Select(Column("id")).Where(Eq(Column("id"), Arg{V: 1})))
+	assert.NoError(t, err)
+
+	err = unsupported(&MySQLWriter{}, UpdateStmt{}, "ReturningStmt", "RETURNING")
+	var ue *UnsupportedError
+	if assert.True(t, errors.As(err, &ue)) {
+		assert.Equal(t, MySQLDialect{}, ue.Dialect)
+		assert.Equal(t, "UpdateStmt", ue.Node)
+		assert.Equal(t, "ReturningStmt", ue.Clause)
+	}
+}
+
+func TestErrors_WriterPassedThrough(t *testing.T) {
+	ioErr := errors.New("disk full")
+	err := From(TableName("users")).WriteSQLTo(&failingWriter{err: ioErr})
+	assert.Equal(t, ioErr, err)
+	assert.False(t, errors.Is(err, ErrInvalidStatement))
+}
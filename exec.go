@@ -0,0 +1,71 @@
+package sqb
+
+import (
+	"context"
+	"database/sql"
+)
+
+// QueryerContext is implemented by *sql.DB, *sql.Tx and *sql.Conn.
+type QueryerContext interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// QueryRowerContext is implemented by *sql.DB, *sql.Tx and *sql.Conn.
+type QueryRowerContext interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// ExecerContext is implemented by *sql.DB, *sql.Tx and *sql.Conn.
+type ExecerContext interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+// RenderError is failure to render statement before it reaches database.
+// Errors of database driver are returned as is.
+type RenderError struct {
+	Err error
+}
+
+func (e *RenderError) Error() string {
+	return "render: " + e.Err.Error()
+}
+
+func (e *RenderError) Unwrap() error {
+	return e.Err
+}
+
+func render(d Dialect, s SQB) (string, []interface{}, error) {
+	query, args, err := d.ToSQL(s)
+	if err != nil {
+		return "", nil, &RenderError{Err: err}
+	}
+	return query, args, nil
+}
+
+// QueryContext renders s in dialect d and runs it with q.
+func QueryContext(ctx context.Context, q QueryerContext, d Dialect, s SQB) (*sql.Rows, error) {
+	query, args, err := render(d, s)
+	if err != nil {
+		return nil, err
+	}
+	return q.QueryContext(ctx, query, args...)
+}
+
+// QueryRowContext renders s in dialect d and runs it with q.
+// Errors of driver are deferred to Scan of returned row.
+func QueryRowContext(ctx context.Context, q QueryRowerContext, d Dialect, s SQB) (*sql.Row, error) {
+	query, args, err := render(d, s)
+	if err != nil {
+		return nil, err
+	}
+	return q.QueryRowContext(ctx, query, args...), nil
+}
+
+// ExecContext renders s in dialect d and executes it with e.
+func ExecContext(ctx context.Context, e ExecerContext, d Dialect, s SQB) (sql.Result, error) {
+	query, args, err := render(d, s)
+	if err != nil {
+		return nil, err
+	}
+	return e.ExecContext(ctx, query, args...)
+}
@@ -0,0 +1,69 @@
+package sqb
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryContext(t *testing.T) {
+	db, fdb := openFakeDB(t, []string{"id"}, []driver.Value{int64(1)})
+	defer db.Close()
+	ctx := context.Background()
+
+	rows, err := QueryContext(ctx, db, PostgreSQLDialect{}, From(TableName("users")).Select(Column("id")).Where(Eq(Column("city"), Arg{V: "Oslo"})))
+	assert.NoError(t, err)
+	var ids []int64
+	for rows.Next() {
+		var id int64
+		assert.NoError(t, rows.Scan(&id))
+		ids = append(ids, id)
+	}
+	assert.NoError(t, rows.Err())
+	assert.Equal(t, []int64{1}, ids)
+	assert.Equal(t, []Query{{SQL: "SELECT id FROM users WHERE (city=$1)", Args: []interface{}{"Oslo"}}}, fdb.queries)
+
+	row, err := QueryRowContext(ctx, db, DefaultDialect{}, From(TableName("users")).Select(Count()))
+	assert.NoError(t, err)
+	var cnt int64
+	assert.NoError(t, row.Scan(&cnt))
+	assert.Equal(t, int64(1), cnt)
+}
+
+func TestExecContext(t *testing.T) {
+	db, fdb := openFakeDB(t, nil, nil, nil)
+	defer db.Close()
+	ctx := context.Background()
+
+	tx, err := db.BeginTx(ctx, nil)
+	assert.NoError(t, err)
+	res, err := ExecContext(ctx, tx, DefaultDialect{}, UpdateStmt{
+		Table: TableName("users"),
+		Set:   SetStmt{{Key: Column("name"), Value: Arg{V: "alice"}}},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, tx.Commit())
+	affected, err := res.RowsAffected()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), affected)
+	assert.Equal(t, []Query{{SQL: "UPDATE users SET name = ?", Args: []interface{}{"alice"}}}, fdb.queries)
+}
+
+func TestExecContext_Errors(t *testing.T) {
+	db, fdb := openFakeDB(t, nil)
+	defer db.Close()
+	ctx := context.Background()
+
+	_, err := ExecContext(ctx, db, DefaultDialect{}, From(TableName("users")).Where(In(Column("id"))))
+	var re *RenderError
+	assert.True(t, errors.As(err, &re))
+	assert.Empty(t, fdb.queries)
+
+	driverErr := errors.New("connection refused")
+	fdb.err = driverErr
+	_, err = ExecContext(ctx, db, DefaultDialect{}, From(TableName("users")))
+	assert.Equal(t, driverErr, err)
+}
@@ -0,0 +1,119 @@
+package sqb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+)
+
+// fakeDriver is in-process database/sql driver which records queries
+// and answers them with canned rows of fakeDB found by DSN.
+type fakeDriver struct{}
+
+var fakeDBs sync.Map
+
+func init() {
+	sql.Register("sqbfake", fakeDriver{})
+}
+
+type fakeDB struct {
+	mu      sync.Mutex
+	queries []Query
+	columns []string
+	rows    [][]driver.Value
+	err     error
+}
+
+func openFakeDB(t *testing.T, columns []string, rows ...[]driver.Value) (*sql.DB, *fakeDB) {
+	fdb := &fakeDB{columns: columns, rows: rows}
+	fakeDBs.Store(t.Name(), fdb)
+	db, err := sql.Open("sqbfake", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db, fdb
+}
+
+func (fdb *fakeDB) record(query string, args []driver.NamedValue) error {
+	fdb.mu.Lock()
+	defer fdb.mu.Unlock()
+	q := Query{SQL: query}
+	for _, a := range args {
+		q.Args = append(q.Args, a.Value)
+	}
+	fdb.queries = append(fdb.queries, q)
+	return fdb.err
+}
+
+func (fakeDriver) Open(dsn string) (driver.Conn, error) {
+	fdb, ok := fakeDBs.Load(dsn)
+	if !ok {
+		return nil, errors.New("fake: unknown database " + dsn)
+	}
+	return &fakeConn{db: fdb.(*fakeDB)}, nil
+}
+
+type fakeConn struct {
+	db *fakeDB
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return nil, errors.New("fake: prepare is not supported")
+}
+
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return c, nil
+}
+
+func (c *fakeConn) Commit() error {
+	return nil
+}
+
+func (c *fakeConn) Rollback() error {
+	return nil
+}
+
+func (c *fakeConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if err := c.db.record(query, args); err != nil {
+		return nil, err
+	}
+	return &fakeRows{columns: c.db.columns, rows: c.db.rows}, nil
+}
+
+func (c *fakeConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	if err := c.db.record(query, args); err != nil {
+		return nil, err
+	}
+	return driver.RowsAffected(len(c.db.rows)), nil
+}
+
+type fakeRows struct {
+	columns []string
+	rows    [][]driver.Value
+	pos     int
+}
+
+func (r *fakeRows) Columns() []string {
+	return r.columns
+}
+
+func (r *fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.pos >= len(r.rows) {
+		return io.EOF
+	}
+	copy(dest, r.rows[r.pos])
+	r.pos++
+	return nil
+}
@@ -0,0 +1,86 @@
+package sqb
+
+import (
+	"hash/fnv"
+	"strconv"
+)
+
+// Normalize renders statement with ? placeholders independent of argument values:
+// IN lists of arguments are collapsed into single placeholder and
+// multi-row VALUES of the same shape into single row.
+func Normalize(s SQB) (string, error) {
+	sql, _, err := ToSQL(Rewrite(s, normalizeNode))
+	return sql, err
+}
+
+// Fingerprint is stable identifier of statement shape, it is hash of Normalize result.
+// Statements which differ only by argument values or lengths of IN lists
+// have the same fingerprint.
+func Fingerprint(s SQB) (string, error) {
+	sql, err := Normalize(s)
+	if err != nil {
+		return "", err
+	}
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(sql))
+	return strconv.FormatUint(h.Sum64(), 16), nil
+}
+
+func normalizeNode(node SQB) SQB {
+	switch n := node.(type) {
+	case Arg:
+		return Arg{}
+	case InExpr:
+		if allArgs(n.List) {
+			n.List = []Comparable{Arg{}}
+		}
+		return n
+	case OnInExpr:
+		if allArgs(n.In) {
+			n.In = []Comparable{Arg{}}
+		}
+		return n
+	case InsertValuesStmt:
+		if len(n) > 1 && sameRows(n) {
+			return n[:1]
+		}
+	}
+	return node
+}
+
+// argument is implemented by Arg and types embedding it, like TypedArg.
+type argument interface {
+	argument() Arg
+}
+
+func (a Arg) argument() Arg { return a }
+
+func allArgs(list []Comparable) bool {
+	for _, c := range list {
+		if _, ok := c.(argument); !ok {
+			return false
+		}
+	}
+	return len(list) > 0
+}
+
+// sameRows reports whether all rows render the same way.
+func sameRows(rows InsertValuesStmt) bool {
+	first, err := renderLine(rows[0])
+	if err != nil {
+		return false
+	}
+	for _, row := range rows[1:] {
+		sql, err := renderLine(row)
+		if err != nil || sql != first {
+			return false
+		}
+	}
+	return true
+}
+
+func renderLine(row []InsertValue) (string, error) {
+	w := &DefaultSQLWriter{}
+	err := writeLine(w, row)
+	return w.String(), err
+}
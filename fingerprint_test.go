@@ -0,0 +1,69 @@
+package sqb
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	users := TableName("users")
+	id := Column("id")
+	var tests = []struct {
+		name     string
+		sqb      SQB
+		expected string
+	}{
+		{
+			name:     "args",
+			sqb:      From(users).Where(Eq(id, Arg{V: 1})),
+			expected: "SELECT * FROM users WHERE (id=?)",
+		},
+		{
+			name:     "in list collapsed",
+			sqb:      From(users).Where(In(id, Arg{V: 1}, Arg{V: 2}, ArgOf(3))),
+			expected: "SELECT * FROM users WHERE (id IN (?))",
+		},
+		{
+			name:     "in list with columns kept",
+			sqb:      From(users).Where(In(id, Arg{V: 1}, Column("parent_id"))),
+			expected: "SELECT * FROM users WHERE (id IN (?, parent_id))",
+		},
+		{
+			name:     "values rows collapsed",
+			sqb:      Insert(users, []Column{id}, InsertValuesStmt{{Arg{V: 1}}, {Arg{V: 2}}}),
+			expected: "INSERT INTO users(id) VALUES (?)",
+		},
+		{
+			name:     "values rows of different shape kept",
+			sqb:      Insert(users, []Column{id}, InsertValuesStmt{{Arg{V: 1}}, {Default}}),
+			expected: "INSERT INTO users(id) VALUES (?), (DEFAULT)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, err := Normalize(tt.sqb)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, sql)
+		})
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	users := TableName("users")
+	id := Column("id")
+	fp := func(s SQB) string {
+		f, err := Fingerprint(s)
+		assert.NoError(t, err)
+		return f
+	}
+
+	base := fp(From(users).Where(Eq(id, Arg{V: 1}), In(Column("role"), Arg{V: "a"})))
+	assert.Equal(t, base, fp(From(users).Where(Eq(id, Arg{V: 2}), In(Column("role"), Arg{V: "b"}, Arg{V: "c"}))))
+	assert.NotEqual(t, base, fp(From(users).Where(Eq(Column("parent_id"), Arg{V: 1}), In(Column("role"), Arg{V: "a"}))))
+	assert.NotEqual(t, base, fp(From(users).Where(BinaryOp(id, ">", Arg{V: 1}), In(Column("role"), Arg{V: "a"}))))
+
+	_, err := Fingerprint(SelectStmt{})
+	assert.True(t, errors.Is(err, ErrInvalidStatement))
+}
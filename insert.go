@@ -1,10 +1,38 @@
 package sqb
 
+import "fmt"
+
 type InsertStmt struct {
 	Table         TableIdentifier
 	Columns       []Column
 	Source        InsertSource
 	ReturningStmt ReturningStmt
+	Modifier      InsertModifier
+	// OnConflictStmt is upsert clause of PostgreSQL and SQLite.
+	OnConflictStmt OnConflictStmt
+}
+
+// InsertModifier is how INSERT treats rows duplicating existing unique keys,
+// dialects other than MySQL and SQLite refuse it.
+type InsertModifier string
+
+const (
+	// InsertIgnore skips duplicate rows: INSERT IGNORE or INSERT OR IGNORE.
+	InsertIgnore InsertModifier = "IGNORE"
+	// InsertReplace deletes existing rows before insert: REPLACE INTO or INSERT OR REPLACE.
+	InsertReplace InsertModifier = "REPLACE"
+)
+
+// Ignore makes INSERT IGNORE, or INSERT OR IGNORE in SQLite.
+func (is InsertStmt) Ignore() InsertStmt {
+	is.Modifier = InsertIgnore
+	return is
+}
+
+// Replace makes REPLACE INTO, or INSERT OR REPLACE in SQLite.
+func (is InsertStmt) Replace() InsertStmt {
+	is.Modifier = InsertReplace
+	return is
 }
 
 type InsertSource interface {
@@ -24,7 +52,12 @@ func Insert(table TableIdentifier, columns []Column, source InsertSource) Insert
 }
 
 func (is InsertStmt) WriteSQLTo(w SQLWriter) error {
-	_, err := w.WriteString(`INSERT INTO `)
+	err := beginStmt(w)
+	if err != nil {
+		return err
+	}
+
+	err = is.writeVerb(w)
 	if err != nil {
 		return err
 	}
@@ -59,24 +92,76 @@ func (is InsertStmt) WriteSQLTo(w SQLWriter) error {
 			}
 		}
 
-		_, err = w.WriteString(") ")
+		_, err = w.WriteString(")")
 		if err != nil {
 			return err
 		}
 	}
 
-	err = is.Source.WriteSQLTo(w)
+	err = writeClause(w)
 	if err != nil {
 		return err
 	}
+
+	source := is.Source
+	if ss, ok := source.(SelectStmt); ok && ss.WhereStmt.Empty() && !is.OnConflictStmt.Empty() && isSQLite(w) {
+		// SQLite takes ON CONFLICT after SELECT without WHERE for join constraint
+		source = ss.Where(Raw("TRUE"))
+	}
+	err = source.WriteSQLTo(w)
+	if err != nil {
+		return err
+	}
+
+	if !is.OnConflictStmt.Empty() {
+		err = writeClause(w)
+		if err != nil {
+			return err
+		}
+
+		err = is.OnConflictStmt.WriteSQLTo(w)
+		if err != nil {
+			return err
+		}
+	}
 	// must be last statement
 	if is.ReturningStmt.Cols != nil {
+		if isMySQL(w) {
+			return unsupported(w, is, "ReturningStmt", "RETURNING")
+		}
+		if err = sqliteUnsupported(w, is, "ReturningStmt", "RETURNING", sqliteReturning); err != nil {
+			return err
+		}
 		err = is.ReturningStmt.WriteSQLTo(w)
 		if err != nil {
 			return err
 		}
 	}
-	return nil
+	return endStmt(w)
+}
+
+func (is InsertStmt) writeVerb(w SQLWriter) error {
+	switch is.Modifier {
+	case "":
+		_, err := w.WriteString(`INSERT INTO `)
+		return err
+	case InsertIgnore, InsertReplace:
+	default:
+		return invalid(is, "unknown modifier "+string(is.Modifier))
+	}
+
+	if isSQLite(w) {
+		return writeStrings(w, `INSERT OR `, string(is.Modifier), ` INTO `)
+	}
+	if !isMySQL(w) {
+		return unsupported(w, is, "Modifier", "INSERT "+string(is.Modifier))
+	}
+	if is.Modifier == InsertIgnore {
+		_, err := w.WriteString(`INSERT IGNORE INTO `)
+		return err
+	}
+	_, err := w.WriteString(`REPLACE INTO `)
+	return err
 }
 
 type InsertValue interface {
@@ -86,11 +171,17 @@ type InsertValue interface {
 
 func (arg Arg) IsInsertValue()        {}
 func (dw defaultWord) IsInsertValue() {}
+func (dw defaultWord) IsCol()         {}
 
 type InsertValuesStmt [][]InsertValue
 
+// WriteSQLTo writes rows of INSERT, empty list is DEFAULT VALUES or VALUES () in MySQL.
 func (ivs InsertValuesStmt) WriteSQLTo(w SQLWriter) error {
 	if len(ivs) == 0 {
+		if isMySQL(w) {
+			_, err := w.WriteString("VALUES ()")
+			return err
+		}
 		_, err := w.WriteString("DEFAULT VALUES")
 		if err != nil {
 			return err
@@ -98,23 +189,26 @@ func (ivs InsertValuesStmt) WriteSQLTo(w SQLWriter) error {
 
 		return nil
 	}
+	return ivs.writeRows(w, "")
+}
 
+// writeRows writes VALUES with every row prefixed by row.
+func (ivs InsertValuesStmt) writeRows(w SQLWriter, row string) error {
 	_, err := w.WriteString("VALUES ")
 	if err != nil {
 		return err
 	}
 
-	err = writeLine(w, ivs[0])
-	if err != nil {
-		return err
-	}
-
-	if len(ivs) == 1 {
-		return nil
-	}
+	beginList(w)
+	for i, values := range ivs {
+		if i > 0 {
+			err = writeListSep(w)
+			if err != nil {
+				return err
+			}
+		}
 
-	for _, values := range ivs[1:] {
-		_, err = w.WriteString(", ")
+		_, err = w.WriteString(row)
 		if err != nil {
 			return err
 		}
@@ -124,6 +218,7 @@ func (ivs InsertValuesStmt) WriteSQLTo(w SQLWriter) error {
 			return err
 		}
 	}
+	endList(w)
 
 	return nil
 }
@@ -170,11 +265,116 @@ func writeLine(w SQLWriter, values []InsertValue) error {
 	return nil
 }
 
+// Default is DEFAULT value of column in VALUES and SET, SQLite does not support it.
 var Default InsertValue = defaultWord{}
 
 type defaultWord struct{}
 
 func (dw defaultWord) WriteSQLTo(w SQLWriter) error {
+	if isSQLite(w) {
+		return unsupported(w, dw, "", "DEFAULT")
+	}
 	_, err := w.WriteString("DEFAULT")
 	return err
 }
+
+// Split renders insert of many rows as several statements,
+// each with at most maxArgs bind parameters.
+// Zero maxArgs means limit of dialect d.
+// Statements with source other than VALUES are never split.
+func (is InsertStmt) Split(d Dialect, maxArgs int) ([]Query, error) {
+	if maxArgs == 0 {
+		maxArgs = d.MaxArgs()
+	}
+
+	values, ok := is.Source.(InsertValuesStmt)
+	if !ok || maxArgs == 0 || len(values) == 0 {
+		sql, args, err := d.ToSQL(is)
+		if err != nil {
+			return nil, err
+		}
+		return []Query{{SQL: sql, Args: args}}, nil
+	}
+
+	head := is
+	head.Source = InsertValuesStmt{}
+	base, err := countArgs(d, head)
+	if err != nil {
+		return nil, err
+	}
+
+	var queries []Query
+	start, n := 0, base
+	flush := func(end int) error {
+		part := is
+		part.Source = values[start:end]
+		sql, args, err := d.ToSQL(part)
+		if err != nil {
+			return err
+		}
+		queries = append(queries, Query{SQL: sql, Args: args})
+		start, n = end, base
+		return nil
+	}
+
+	for i, line := range values {
+		cnt, err := countArgs(d, insertLine(line))
+		if err != nil {
+			return nil, err
+		}
+
+		if base+cnt > maxArgs {
+			return nil, invalid(is, fmt.Sprintf("row %d needs %d args, limit is %d", i, base+cnt, maxArgs))
+		}
+
+		if n+cnt > maxArgs {
+			err = flush(i)
+			if err != nil {
+				return nil, err
+			}
+		}
+		n += cnt
+	}
+
+	err = flush(len(values))
+	if err != nil {
+		return nil, err
+	}
+	return queries, nil
+}
+
+type insertLine []InsertValue
+
+func (il insertLine) WriteSQLTo(w SQLWriter) error {
+	return writeLine(w, il)
+}
+
+// argsCounter is SQLWriter of dialect d which only counts arguments.
+type argsCounter struct {
+	d Dialect
+	n int
+}
+
+func (ac *argsCounter) Dialect() Dialect {
+	return ac.d
+}
+
+func (ac *argsCounter) AddArgs(interface{}) error {
+	ac.n++
+	return nil
+}
+
+func (ac *argsCounter) WriteString(s string) (int, error) {
+	return len(s), nil
+}
+
+func (ac *argsCounter) AppendRawArgs(a ...interface{}) error {
+	ac.n += len(a)
+	return nil
+}
+
+func countArgs(d Dialect, s SQB) (int, error) {
+	ac := &argsCounter{d: d}
+	err := s.WriteSQLTo(ac)
+	return ac.n, err
+}
@@ -0,0 +1,104 @@
+package sqb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInsertStmt_Split(t *testing.T) {
+	rows := InsertValuesStmt{
+		{Arg{V: 1}, Arg{V: "a"}},
+		{Arg{V: 2}, Default},
+		{Arg{V: 3}, Arg{V: "c"}},
+	}
+	tests := []struct {
+		name            string
+		sqb             InsertStmt
+		dialect         Dialect
+		maxArgs         int
+		wantErr         bool
+		expectedQueries []Query
+	}{
+		{
+			name:    "fits into one statement",
+			sqb:     Insert(TableName("users"), []Column{"id", "name"}, rows),
+			dialect: PostgreSQLDialect{},
+			expectedQueries: []Query{
+				{
+					SQL:  "INSERT INTO users(id, name) VALUES ($1, $2), ($3, DEFAULT), ($4, $5)",
+					Args: []interface{}{1, "a", 2, 3, "c"},
+				},
+			},
+		},
+		{
+			name:    "split by limit",
+			sqb:     Insert(TableName("users"), []Column{"id", "name"}, rows),
+			dialect: PostgreSQLDialect{},
+			maxArgs: 3,
+			expectedQueries: []Query{
+				{
+					SQL:  "INSERT INTO users(id, name) VALUES ($1, $2), ($3, DEFAULT)",
+					Args: []interface{}{1, "a", 2},
+				},
+				{
+					SQL:  "INSERT INTO users(id, name) VALUES ($1, $2)",
+					Args: []interface{}{3, "c"},
+				},
+			},
+		},
+		{
+			name:    "returning args are counted in every statement",
+			sqb:     Insert(TableName("users"), []Column{"id", "name"}, rows).Returning(Arg{V: "x"}),
+			dialect: DefaultDialect{},
+			maxArgs: 3,
+			expectedQueries: []Query{
+				{
+					SQL:  "INSERT INTO users(id, name) VALUES (?, ?) RETURNING ?",
+					Args: []interface{}{1, "a", "x"},
+				},
+				{
+					SQL:  "INSERT INTO users(id, name) VALUES (?, DEFAULT) RETURNING ?",
+					Args: []interface{}{2, "x"},
+				},
+				{
+					SQL:  "INSERT INTO users(id, name) VALUES (?, ?) RETURNING ?",
+					Args: []interface{}{3, "c", "x"},
+				},
+			},
+		},
+		{
+			name:    "select source is not split",
+			sqb:     Insert(TableName("users"), []Column{"id"}, From(TableName("old_users")).Select(Column("id"))),
+			dialect: DefaultDialect{},
+			maxArgs: 1,
+			expectedQueries: []Query{
+				{SQL: "INSERT INTO users(id) SELECT id FROM old_users"},
+			},
+		},
+		{
+			name:    "without columns",
+			sqb:     Insert(TableName("users"), nil, From(TableName("old_users"))),
+			dialect: DefaultDialect{},
+			expectedQueries: []Query{
+				{SQL: "INSERT INTO users SELECT * FROM old_users"},
+			},
+		},
+		{
+			name:    "row exceeds limit",
+			sqb:     Insert(TableName("users"), []Column{"id", "name"}, rows),
+			dialect: DefaultDialect{},
+			maxArgs: 1,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queries, err := tt.sqb.Split(tt.dialect, tt.maxArgs)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Split() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.expectedQueries, queries)
+		})
+	}
+}
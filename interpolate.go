@@ -0,0 +1,164 @@
+package sqb
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// InterpolatingWriter renders arguments as escaped SQL literals of Dialect
+// instead of placeholders, so output can be pasted into database console.
+// It is meant for logs and tests only, never execute its output.
+type InterpolatingWriter struct {
+	strings.Builder
+	D Dialect
+}
+
+func (iw *InterpolatingWriter) AddArgs(a interface{}) error {
+	lit, err := literal(iw.Dialect(), a)
+	if err != nil {
+		return err
+	}
+	_, err = iw.WriteString(lit)
+	return err
+}
+
+// AppendRawArgs fails because placeholders of raw SQL are already written.
+func (iw *InterpolatingWriter) AppendRawArgs(a ...interface{}) error {
+	if len(a) == 0 {
+		return nil
+	}
+	return &UnsupportedError{Dialect: iw.Dialect(), Node: "RawSQL", Feature: "interpolation of raw SQL arguments"}
+}
+
+func (iw *InterpolatingWriter) Dialect() Dialect {
+	if iw.D == nil {
+		return DefaultDialect{}
+	}
+	return iw.D
+}
+
+// Interpolate renders statement with inlined arguments for debugging.
+func Interpolate(d Dialect, s SQB) (string, error) {
+	err := Validate(s)
+	if err != nil {
+		return "", err
+	}
+	iw := &InterpolatingWriter{D: d}
+	err = s.WriteSQLTo(iw)
+	if err != nil {
+		return "", err
+	}
+	return iw.String(), nil
+}
+
+const (
+	pgTimeFormat    = "2006-01-02 15:04:05.999999-07:00"
+	mysqlTimeFormat = "2006-01-02 15:04:05.999999"
+)
+
+// literal formats v as SQL literal, types which can not be safely formatted are refused.
+func literal(d Dialect, v interface{}) (string, error) {
+	if vr, ok := v.(driver.Valuer); ok {
+		rv := reflect.ValueOf(v)
+		if rv.Kind() == reflect.Ptr && rv.IsNil() {
+			return "NULL", nil
+		}
+		dv, err := vr.Value()
+		if err != nil {
+			return "", err
+		}
+		if _, ok := dv.(driver.Valuer); ok {
+			return "", unsupportedLiteral(d, v)
+		}
+		return literal(d, dv)
+	}
+
+	switch v := v.(type) {
+	case nil:
+		return "NULL", nil
+	case time.Time:
+		return timeLiteral(d, v), nil
+	case []byte:
+		if v == nil {
+			return "NULL", nil
+		}
+		return bytesLiteral(d, v), nil
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Ptr:
+		if rv.IsNil() {
+			return "NULL", nil
+		}
+		return literal(d, rv.Elem().Interface())
+	case reflect.Bool:
+		if rv.Bool() {
+			return "TRUE", nil
+		}
+		return "FALSE", nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		f := rv.Float()
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return "", unsupportedLiteral(d, v)
+		}
+		return strconv.FormatFloat(f, 'g', -1, rv.Type().Bits()), nil
+	case reflect.String:
+		return stringLiteral(d, rv.String())
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			return literal(d, rv.Bytes())
+		}
+	}
+	return "", unsupportedLiteral(d, v)
+}
+
+func unsupportedLiteral(d Dialect, v interface{}) error {
+	return &UnsupportedError{Dialect: d, Node: "Arg", Feature: fmt.Sprintf("literal of %T", v)}
+}
+
+var mysqlEscaper = strings.NewReplacer(
+	"\\", "\\\\",
+	"'", "\\'",
+	"\x00", "\\0",
+	"\n", "\\n",
+	"\r", "\\r",
+	"\x1a", "\\Z",
+)
+
+func stringLiteral(d Dialect, s string) (string, error) {
+	if isMySQLDialect(d) {
+		return "'" + mysqlEscaper.Replace(s) + "'", nil
+	}
+	if strings.IndexByte(s, 0) >= 0 {
+		return "", &UnsupportedError{Dialect: d, Node: "Arg", Feature: "NUL character in string literal"}
+	}
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'", nil
+}
+
+func bytesLiteral(d Dialect, b []byte) string {
+	if _, ok := d.(PostgreSQLDialect); ok {
+		return `'\x` + hex.EncodeToString(b) + "'::bytea"
+	}
+	return "X'" + hex.EncodeToString(b) + "'"
+}
+
+func timeLiteral(d Dialect, t time.Time) string {
+	switch d.(type) {
+	case PostgreSQLDialect:
+		return "'" + t.Format(pgTimeFormat) + "'::timestamptz"
+	case MySQLDialect:
+		return "'" + t.Format(mysqlTimeFormat) + "'"
+	}
+	return "'" + t.Format(pgTimeFormat) + "'"
+}
@@ -0,0 +1,88 @@
+package sqb
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterpolate(t *testing.T) {
+	ts := time.Date(2020, 1, 2, 3, 4, 5, 600000000, time.FixedZone("", 3*60*60))
+	users := TableName("users")
+
+	tests := []struct {
+		name     string
+		dialect  Dialect
+		sqb      SQB
+		expected string
+	}{
+		{
+			name:     "default",
+			dialect:  DefaultDialect{},
+			sqb:      From(users).Where(Eq(Column("name"), Arg{V: "O'Reilly"}), Eq(Column("active"), Arg{V: true}), In(Column("id"), Arg{V: 1}, Arg{V: uint8(2)}, Arg{V: 2.5})),
+			expected: "SELECT * FROM users WHERE (name='O''Reilly') AND (active=TRUE) AND (id IN (1, 2, 2.5))",
+		},
+		{
+			name:     "nil default",
+			dialect:  nil,
+			sqb:      From(users).Where(Eq(Column("name"), Arg{V: nil}), Eq(Column("data"), Arg{V: []byte{0xde, 0xad}})),
+			expected: "SELECT * FROM users WHERE (name=NULL) AND (data=X'dead')",
+		},
+		{
+			name:     "postgresql",
+			dialect:  PostgreSQLDialect{},
+			sqb:      From(users).Where(Eq(Column("data"), Arg{V: []byte{0xbe, 0xef}}), BinaryOp(Column("created_at"), ">", Arg{V: ts}), Eq(Column("path"), Arg{V: `C:\tmp`})),
+			expected: `SELECT * FROM users WHERE (data='\xbeef'::bytea) AND (created_at > '2020-01-02 03:04:05.6+03:00'::timestamptz) AND (path='C:\tmp')`,
+		},
+		{
+			name:     "mysql",
+			dialect:  MySQLDialect{},
+			sqb:      From(users).Where(Eq(Column("name"), Arg{V: "it's\\\n"}), BinaryOp(Column("created_at"), ">", Arg{V: ts})),
+			expected: `SELECT * FROM users WHERE (name='it\'s\\\n') AND (created_at > '2020-01-02 03:04:05.6')`,
+		},
+		{
+			name:     "valuer",
+			dialect:  DefaultDialect{},
+			sqb:      From(users).Where(Eq(Column("name"), Arg{V: sql.NullString{}}), Eq(Column("age"), Arg{V: sql.NullInt64{Int64: 7, Valid: true}})),
+			expected: "SELECT * FROM users WHERE (name=NULL) AND (age=7)",
+		},
+		{
+			name:     "pointers",
+			dialect:  DefaultDialect{},
+			sqb:      From(users).Where(Eq(Column("name"), Arg{V: (*string)(nil)}), Eq(Column("age"), Arg{V: new(int)})),
+			expected: "SELECT * FROM users WHERE (name=NULL) AND (age=0)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := Interpolate(tt.dialect, tt.sqb)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.expected, s)
+			}
+		})
+	}
+}
+
+func TestInterpolate_Refused(t *testing.T) {
+	tests := []struct {
+		name string
+		sqb  SQB
+	}{
+		{name: "slice", sqb: From(TableName("users")).Where(Eq(Column("id"), Arg{V: []int{1}}))},
+		{name: "struct", sqb: From(TableName("users")).Where(Eq(Column("id"), Arg{V: struct{}{}}))},
+		{name: "nan", sqb: From(TableName("users")).Where(Eq(Column("id"), Arg{V: 0 / zero}))},
+		{name: "nul", sqb: From(TableName("users")).Where(Eq(Column("id"), Arg{V: "a\x00"}))},
+		{name: "raw args", sqb: From(TableName("users")).Where(Raw("id = ?", 1))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Interpolate(PostgreSQLDialect{}, tt.sqb)
+			assert.True(t, errors.Is(err, ErrUnsupported), "unexpected error %v", err)
+		})
+	}
+}
+
+var zero = 0.0
@@ -25,6 +25,9 @@ func (jb JoinBuilder) FullOuterJoin(arg Joinable, on OnExpr) JoinBuilder {
 	return JoinBuilder{Joinable: FullOuterJoin(jb.Joinable, arg, on)}
 }
 
+// CrossJoin never renders on, Validate reports it if it is not nil.
 func (jb JoinBuilder) CrossJoin(arg Joinable, on OnExpr) JoinBuilder {
-	return JoinBuilder{Joinable: CrossJoin(jb.Joinable, arg)}
+	cj := CrossJoin(jb.Joinable, arg)
+	cj.discardedOn = on
+	return JoinBuilder{Joinable: cj}
 }
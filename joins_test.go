@@ -36,6 +36,7 @@ func TestJB_LeftJoin(t *testing.T) {
 				t.Errorf("WriteSQLTo() raw SQL expected = %v, actual = %v", tt.expectedRawSQL, builded)
 			}
 			assert.Equal(t, tt.expectedArgs, tsw.Args)
+			assertRoundTrip(t, DefaultDialect{}, tt.sqb)
 		})
 	}
 }
@@ -0,0 +1,107 @@
+package sqb
+
+// IndexHintKind is kind of MySQL index hint.
+type IndexHintKind string
+
+const (
+	HintUse    IndexHintKind = "USE"
+	HintForce  IndexHintKind = "FORCE"
+	HintIgnore IndexHintKind = "IGNORE"
+)
+
+// IndexHint is MySQL index hint: USE INDEX (idx1, idx2).
+type IndexHint struct {
+	Kind    IndexHintKind
+	Indexes []string
+}
+
+func (ih IndexHint) WriteSQLTo(w SQLWriter) error {
+	err := writeStrings(w, " ", string(ih.Kind), " INDEX (")
+	if err != nil {
+		return err
+	}
+	for i, idx := range ih.Indexes {
+		if i > 0 {
+			_, err = w.WriteString(", ")
+			if err != nil {
+				return err
+			}
+		}
+		err = writeIdent(w, idx)
+		if err != nil {
+			return err
+		}
+	}
+	_, err = w.WriteString(")")
+	return err
+}
+
+// HintedTable is table with MySQL index hints, other dialects refuse it.
+// Table is TableIdentifier or TableIdentifierAlias.
+type HintedTable struct {
+	Table Joinable
+	Hints []IndexHint
+}
+
+func (HintedTable) IsTable()    {}
+func (HintedTable) IsJoinable() {}
+
+func (tn TableIdentifier) UseIndex(indexes ...string) HintedTable {
+	return HintedTable{Table: tn}.UseIndex(indexes...)
+}
+
+func (tn TableIdentifier) ForceIndex(indexes ...string) HintedTable {
+	return HintedTable{Table: tn}.ForceIndex(indexes...)
+}
+
+func (tn TableIdentifier) IgnoreIndex(indexes ...string) HintedTable {
+	return HintedTable{Table: tn}.IgnoreIndex(indexes...)
+}
+
+func (tn TableIdentifierAlias) UseIndex(indexes ...string) HintedTable {
+	return HintedTable{Table: tn}.UseIndex(indexes...)
+}
+
+func (tn TableIdentifierAlias) ForceIndex(indexes ...string) HintedTable {
+	return HintedTable{Table: tn}.ForceIndex(indexes...)
+}
+
+func (tn TableIdentifierAlias) IgnoreIndex(indexes ...string) HintedTable {
+	return HintedTable{Table: tn}.IgnoreIndex(indexes...)
+}
+
+func (ht HintedTable) UseIndex(indexes ...string) HintedTable {
+	return ht.hint(HintUse, indexes)
+}
+
+func (ht HintedTable) ForceIndex(indexes ...string) HintedTable {
+	return ht.hint(HintForce, indexes)
+}
+
+func (ht HintedTable) IgnoreIndex(indexes ...string) HintedTable {
+	return ht.hint(HintIgnore, indexes)
+}
+
+func (ht HintedTable) hint(kind IndexHintKind, indexes []string) HintedTable {
+	hints := make([]IndexHint, 0, len(ht.Hints)+1)
+	hints = append(hints, ht.Hints...)
+	ht.Hints = append(hints, IndexHint{Kind: kind, Indexes: indexes})
+	return ht
+}
+
+func (ht HintedTable) WriteSQLTo(w SQLWriter) error {
+	if !isMySQL(w) {
+		return unsupported(w, ht, "Hints", "index hints")
+	}
+	err := ht.Table.WriteSQLTo(w)
+	if err != nil {
+		return err
+	}
+	for _, h := range ht.Hints {
+		err = h.WriteSQLTo(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package sqb
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMySQLDialect(t *testing.T) {
+	users := TableName("users")
+	var tests = []struct {
+		name         string
+		dialect      MySQLDialect
+		sqb          SQB
+		expectedSQL  string
+		expectedArgs []interface{}
+	}{
+		{
+			name:    "quoted identifiers",
+			dialect: MySQLDialect{QuoteIdents: true},
+			sqb: From(JB(users.As("u")).LeftJoin(TableName("orders").As("o"), Eq(Column("o.user_id"), Column("u.id")))).
+				Select(Column("u.*"), Column("o.order").As("last order"), Count(Column("o.id"))).
+				Where(Eq(Column("u.na`me"), Arg{V: "alice"})).
+				GroupBy(Column("u.id")),
+			expectedSQL:  "SELECT `u`.*, `o`.`order` AS `last order`, COUNT(`o`.`id`) FROM `users` AS `u` LEFT JOIN `orders` AS `o` ON `o`.`user_id`=`u`.`id` WHERE (`u`.`na``me`=?) GROUP BY `u`.`id`",
+			expectedArgs: []interface{}{"alice"},
+		},
+		{
+			name:         "quoted prefix",
+			dialect:      MySQLDialect{QuoteIdents: true},
+			sqb:          From(users).SelectList(NewColumnList(Column("id"), Column("key")).WithPrefix("users")),
+			expectedSQL:  "SELECT `users`.`id`, `users`.`key` FROM `users`",
+			expectedArgs: nil,
+		},
+		{
+			name:    "quoted insert and update",
+			dialect: MySQLDialect{QuoteIdents: true},
+			sqb: UpdateStmt{
+				Table:     users,
+				Set:       SetStmt{{Key: "order", Value: Arg{V: 1}}},
+				WhereStmt: WhereStmt{Exprs: []BoolExpr{Eq(Column("id"), Arg{V: 2})}},
+			},
+			expectedSQL:  "UPDATE `users` SET `order` = ? WHERE (`id`=?)",
+			expectedArgs: []interface{}{1, 2},
+		},
+		{
+			name:         "alias quoted without QuoteIdents",
+			sqb:          From(users).Select(Column("id").As("user id")),
+			expectedSQL:  "SELECT id AS `user id` FROM users",
+			expectedArgs: nil,
+		},
+		{
+			name:         "limit offset",
+			sqb:          From(users).Limit(10).Offset(20),
+			expectedSQL:  "SELECT * FROM users LIMIT 10 OFFSET 20",
+			expectedArgs: nil,
+		},
+		{
+			name:         "limit comma",
+			dialect:      MySQLDialect{LimitComma: true},
+			sqb:          From(users).Limit(10).Offset(20),
+			expectedSQL:  "SELECT * FROM users LIMIT 20, 10",
+			expectedArgs: nil,
+		},
+		{
+			name:         "limit comma without offset",
+			dialect:      MySQLDialect{LimitComma: true},
+			sqb:          From(users).Limit(10),
+			expectedSQL:  "SELECT * FROM users LIMIT 10",
+			expectedArgs: nil,
+		},
+		{
+			name:         "insert ignore",
+			sqb:          Insert(users, []Column{"id"}, InsertValuesStmt{{Arg{V: 1}}}).Ignore(),
+			expectedSQL:  "INSERT IGNORE INTO users(id) VALUES (?)",
+			expectedArgs: []interface{}{1},
+		},
+		{
+			name:         "replace",
+			dialect:      MySQLDialect{QuoteIdents: true},
+			sqb:          Insert(users, []Column{"id"}, InsertValuesStmt{{Arg{V: 1}}}).Replace(),
+			expectedSQL:  "REPLACE INTO `users`(`id`) VALUES (?)",
+			expectedArgs: []interface{}{1},
+		},
+		{
+			name:    "values table",
+			dialect: MySQLDialect{QuoteIdents: true},
+			sqb: UpdateStmt{
+				Table: users,
+				Set:   SetStmt{{Key: "users.name", Value: Column("v.name")}},
+				From: UpdateFromStmt{
+					Table: InsertValuesStmt{{Arg{V: 1}, Arg{V: "alice"}}, {Arg{V: 2}, Arg{V: "bob"}}}.As("v", "id", "name"),
+					On:    Eq(Column("users.id"), Column("v.id")),
+				},
+			},
+			expectedSQL:  "UPDATE `users` INNER JOIN (VALUES ROW(?, ?), ROW(?, ?)) AS `v`(`id`, `name`) ON `users`.`id`=`v`.`id` SET `users`.`name` = `v`.`name`",
+			expectedArgs: []interface{}{1, "alice", 2, "bob"},
+		},
+		{
+			name:         "default values",
+			sqb:          Insert(users, nil, InsertValuesStmt{}),
+			expectedSQL:  "INSERT INTO users VALUES ()",
+			expectedArgs: nil,
+		},
+		{
+			name:         "index hints",
+			dialect:      MySQLDialect{QuoteIdents: true},
+			sqb:          From(JB(users.As("u").UseIndex("idx_city", "idx_age").IgnoreIndex("PRIMARY")).InnerJoin(TableName("orders").ForceIndex("idx_user"), Eq(Column("orders.user_id"), Column("u.id")))),
+			expectedSQL:  "SELECT * FROM `users` AS `u` USE INDEX (`idx_city`, `idx_age`) IGNORE INDEX (`PRIMARY`) INNER JOIN `orders` FORCE INDEX (`idx_user`) ON `orders`.`user_id`=`u`.`id`",
+			expectedArgs: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args, err := tt.dialect.ToSQL(tt.sqb)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedSQL, query)
+			assert.Equal(t, tt.expectedArgs, args)
+			assertRoundTrip(t, tt.dialect, tt.sqb)
+		})
+	}
+}
+
+func TestMySQLDialect_Unsupported(t *testing.T) {
+	users := TableName("users")
+	full := From(FullOuterJoin(users, TableName("orders"), Eq(Column("orders.user_id"), Column("users.id"))))
+
+	_, _, err := ToMySQL(full)
+	assert.True(t, errors.Is(err, ErrUnsupported))
+	assert.EqualError(t, err, "sqb: MySQL does not support FULL OUTER JOIN (FullOuterJoinStmt)")
+	_, _, err = ToPostgreSql(full)
+	assert.NoError(t, err)
+
+	_, _, err = ToMySQL(Insert(users, []Column{"id"}, InsertValuesStmt{{Arg{V: 1}}}).Returning(Column("id")))
+	assert.EqualError(t, err, "sqb: MySQL does not support RETURNING (InsertStmt.ReturningStmt)")
+
+	_, _, err = ToMySQL(UpdateStmt{Table: users, Set: SetStmt{{Key: Column("name"), Value: Arg{V: "x"}}}}.Returning(Column("id")))
+	var ue *UnsupportedError
+	if assert.True(t, errors.As(err, &ue)) {
+		assert.Equal(t, MySQLDialect{}, ue.Dialect)
+		assert.Equal(t, "UpdateStmt", ue.Node)
+		assert.Equal(t, "ReturningStmt", ue.Clause)
+	}
+
+	_, _, err = ToPostgreSql(Insert(users, []Column{"id"}, InsertValuesStmt{{Arg{V: 1}}}).Ignore())
+	assert.EqualError(t, err, "sqb: PostgreSQL does not support INSERT IGNORE (InsertStmt.Modifier)")
+
+	_, _, err = ToPostgreSql(From(users.UseIndex("idx")))
+	assert.EqualError(t, err, "sqb: PostgreSQL does not support index hints (HintedTable.Hints)")
+
+	_, _, err = ToMySQL(From(HintedTable{Table: users, Hints: []IndexHint{{Kind: HintUse}}}))
+	assert.True(t, errors.Is(err, ErrInvalidStatement))
+}
+
+func TestMySQLDialect_Split(t *testing.T) {
+	s := Insert(TableName("users"), []Column{"id"}, InsertValuesStmt{{Arg{V: 1}}, {Arg{V: 2}}, {Arg{V: 3}}}).Ignore()
+	queries, err := s.Split(MySQLDialect{}, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []Query{
+		{SQL: "INSERT IGNORE INTO users(id) VALUES (?), (?)", Args: []interface{}{1, 2}},
+		{SQL: "INSERT IGNORE INTO users(id) VALUES (?)", Args: []interface{}{3}},
+	}, queries)
+}
+
+func TestRewriter_HintedTable(t *testing.T) {
+	s := testRewriter().Rewrite(From(TableName("posts").As("p").ForceIndex("idx_author")))
+	query, args, err := ToMySQL(s)
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM posts AS p FORCE INDEX (idx_author) WHERE (p.tenant_id=?) AND (p.deleted_at IS NULL)", query)
+	assert.Equal(t, []interface{}{7}, args)
+}
@@ -0,0 +1,144 @@
+package sqb
+
+import (
+	"database/sql"
+	"reflect"
+	"strconv"
+)
+
+// NamedArg is argument bound by name. Dialects with named parameters
+// render it as :name or @name and pass sql.NamedArg, numbered placeholders
+// of PostgreSQL are reused for repeated name, other dialects bind it by position.
+// Name must be identifier, reusing it with different value makes statement invalid.
+type NamedArg struct {
+	Name string
+	V    interface{}
+}
+
+// Named is counterpart of sql.Named.
+func Named(name string, v interface{}) NamedArg {
+	return NamedArg{Name: name, V: v}
+}
+
+func (NamedArg) IsComparable()  {}
+func (NamedArg) IsCol()         {}
+func (NamedArg) IsInsertValue() {}
+
+func (na NamedArg) WriteSQLTo(w SQLWriter) error {
+	if err := na.checkName(); err != nil {
+		return err
+	}
+	if nw, ok := w.(NamedArgWriter); ok {
+		return nw.AddNamedArg(na.Name, na.V)
+	}
+	return w.AddArgs(na.V)
+}
+
+// checkName refuses name which is not identifier, as it is written into SQL as is.
+func (na NamedArg) checkName() error {
+	if !isPlainIdent(na.Name) {
+		return invalid(na, "name "+strconv.Quote(na.Name)+" is not identifier")
+	}
+	return nil
+}
+
+// NamedArgWriter is implemented by writers binding NamedArg by name,
+// others get its value as positional argument.
+type NamedArgWriter interface {
+	AddNamedArg(name string, v interface{}) error
+}
+
+// namedDialect is implemented by dialects with named parameters.
+type namedDialect interface {
+	appendNamed(b []byte, name string) []byte
+}
+
+// OracleDialect is Oracle with :1 and :name placeholders, which sqlx uses as well.
+// Only placeholders are specific to dialect.
+type OracleDialect struct{}
+
+func (d OracleDialect) ToSQL(s SQB) (string, []interface{}, error) {
+	return toSQL(d, s)
+}
+
+func (OracleDialect) MaxArgs() int { return 65535 }
+
+func (OracleDialect) appendPlaceholder(b []byte, n int) []byte {
+	return strconv.AppendInt(append(b, ':'), int64(n), 10)
+}
+
+func (OracleDialect) appendNamed(b []byte, name string) []byte {
+	return append(append(b, ':'), name...)
+}
+
+// SQLServerDialect is SQL Server with @p1 and @name placeholders.
+// Only placeholders are specific to dialect.
+type SQLServerDialect struct{}
+
+func (d SQLServerDialect) ToSQL(s SQB) (string, []interface{}, error) {
+	return toSQL(d, s)
+}
+
+func (SQLServerDialect) MaxArgs() int { return 2100 }
+
+func (SQLServerDialect) appendPlaceholder(b []byte, n int) []byte {
+	return strconv.AppendInt(append(b, "@p"...), int64(n), 10)
+}
+
+func (SQLServerDialect) appendNamed(b []byte, name string) []byte {
+	return append(append(b, '@'), name...)
+}
+
+// namedArgs maps names of bound arguments to their numbers and values.
+type namedArgs map[string]boundArg
+
+type boundArg struct {
+	// n is number of argument, 0 if it is bound by position.
+	n int
+	v interface{}
+}
+
+// bind appends placeholder of named argument to b and its value to args,
+// unless name is already bound. It returns false if d binds arguments by position only,
+// and error if name is already bound to different value.
+func (na *namedArgs) bind(d Dialect, b []byte, args []interface{}, name string, v interface{}) ([]byte, []interface{}, bool, error) {
+	if err := (NamedArg{Name: name, V: v}).checkName(); err != nil {
+		return b, args, false, err
+	}
+	ba, ok := (*na)[name]
+	if ok && !reflect.DeepEqual(ba.v, v) {
+		return b, args, false, invalid(NamedArg{Name: name, V: v}, "argument "+strconv.Quote(name)+" is bound to different values")
+	}
+	if *na == nil {
+		*na = make(namedArgs)
+	}
+
+	nd, named := d.(namedDialect)
+	pd, numbered := d.(placeholderDialect)
+	if !named && !numbered {
+		if !ok {
+			(*na)[name] = boundArg{v: v}
+		}
+		return b, args, false, nil
+	}
+
+	if !ok {
+		if named {
+			args = append(args, sql.Named(name, v))
+		} else {
+			args = append(args, v)
+		}
+		ba = boundArg{n: len(args), v: v}
+		(*na)[name] = ba
+	}
+	if named {
+		return nd.appendNamed(b, name), args, true, nil
+	}
+	return pd.appendPlaceholder(b, ba.n), args, true, nil
+}
+
+func (na namedArgs) reset() {
+	for name := range na {
+		delete(na, name)
+	}
+}
@@ -0,0 +1,128 @@
+package sqb
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNamedArg(t *testing.T) {
+	s := From(TableName("posts")).Where(
+		Or(Eq(Column("author_id"), Named("user", 7)), Eq(Column("editor_id"), Named("user", 7))),
+		Eq(Column("status"), Arg{V: "draft"}),
+	)
+
+	tests := []struct {
+		name         string
+		dialect      Dialect
+		expectedSQL  string
+		expectedArgs []interface{}
+	}{
+		{
+			name:         "postgresql reuses number",
+			dialect:      PostgreSQLDialect{},
+			expectedSQL:  "SELECT * FROM posts WHERE ((author_id=$1) OR (editor_id=$1)) AND (status=$2)",
+			expectedArgs: []interface{}{7, "draft"},
+		},
+		{
+			name:         "oracle",
+			dialect:      OracleDialect{},
+			expectedSQL:  "SELECT * FROM posts WHERE ((author_id=:user) OR (editor_id=:user)) AND (status=:2)",
+			expectedArgs: []interface{}{sql.Named("user", 7), "draft"},
+		},
+		{
+			name:         "sql server",
+			dialect:      SQLServerDialect{},
+			expectedSQL:  "SELECT * FROM posts WHERE ((author_id=@user) OR (editor_id=@user)) AND (status=@p2)",
+			expectedArgs: []interface{}{sql.Named("user", 7), "draft"},
+		},
+		{
+			name:         "mysql binds by position",
+			dialect:      MySQLDialect{},
+			expectedSQL:  "SELECT * FROM posts WHERE ((author_id=?) OR (editor_id=?)) AND (status=?)",
+			expectedArgs: []interface{}{7, 7, "draft"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args, err := tt.dialect.ToSQL(s)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedSQL, query)
+			assert.Equal(t, tt.expectedArgs, args)
+
+			// pooled buffer must not keep names of previous statement
+			_, args, err = tt.dialect.ToSQL(From(TableName("users")).Where(Eq(Column("id"), Named("user", 8))))
+			assert.NoError(t, err)
+			assert.Len(t, args, 1)
+		})
+	}
+}
+
+func TestNamedArg_Writers(t *testing.T) {
+	s := Insert(TableName("users"), []Column{"name", "login"}, InsertValuesStmt{{Named("name", "alice"), Named("name", "alice")}})
+
+	pw := &PostgreSQLWriter{}
+	assert.NoError(t, s.WriteSQLTo(pw))
+	assert.Equal(t, "INSERT INTO users(name, login) VALUES ($1, $1)", pw.String())
+	assert.Equal(t, []interface{}{"alice"}, pw.Args)
+
+	var out bytes.Buffer
+	sw := &StreamWriter{W: &out, D: SQLServerDialect{}}
+	assert.NoError(t, s.WriteSQLTo(sw))
+	assert.Equal(t, "INSERT INTO users(name, login) VALUES (@name, @name)", out.String())
+	assert.Equal(t, []interface{}{sql.Named("name", "alice")}, sw.Args)
+
+	query, err := Interpolate(OracleDialect{}, s)
+	assert.NoError(t, err)
+	assert.Equal(t, "INSERT INTO users(name, login) VALUES ('alice', 'alice')", query)
+
+	_, _, err = ToSQL(From(TableName("users")).Where(Eq(Column("id"), Named("", 1))))
+	assert.True(t, errors.Is(err, ErrInvalidStatement))
+}
+
+func TestNamedArg_InvalidName(t *testing.T) {
+	for _, name := range []string{"", "1st", "id; DROP TABLE users", "a-b", "имя"} {
+		s := From(TableName("users")).Where(Eq(Column("id"), Named(name, 1)))
+
+		err := Validate(s)
+		assert.True(t, errors.Is(err, ErrInvalidStatement), "%q: %v", name, err)
+		var ie *InvalidStatementError
+		assert.True(t, errors.As(err, &ie), "%q: %v", name, err)
+
+		for _, d := range []Dialect{OracleDialect{}, SQLServerDialect{}, PostgreSQLDialect{}, DefaultDialect{}} {
+			_, _, err := ToSQLInto(d, s, nil, nil)
+			assert.True(t, errors.As(err, &ie), "%q %T: %v", name, d, err)
+		}
+		assert.True(t, errors.Is(s.WriteSQLTo(&Buffer{D: OracleDialect{}}), ErrInvalidStatement), name)
+	}
+
+	_, _, err := OracleDialect{}.ToSQL(From(TableName("users")).Where(Eq(Column("id"), Named("_user_id1", 1))))
+	assert.NoError(t, err)
+
+	b := &Buffer{D: SQLServerDialect{}}
+	assert.True(t, errors.Is(b.AddNamedArg("a b", 1), ErrInvalidStatement))
+	assert.Empty(t, b.B)
+}
+
+func TestNamedArg_DifferentValues(t *testing.T) {
+	s := From(TableName("posts")).Where(Eq(Column("author_id"), Named("user", 7)), Eq(Column("editor_id"), Named("user", 8)))
+	for _, d := range []Dialect{PostgreSQLDialect{}, OracleDialect{}, SQLServerDialect{}, MySQLDialect{}} {
+		_, _, err := d.ToSQL(s)
+		assert.True(t, errors.Is(err, ErrInvalidStatement), "%T: %v", d, err)
+	}
+	assert.True(t, errors.Is(s.WriteSQLTo(&PostgreSQLWriter{}), ErrInvalidStatement))
+
+	_, _, err := PostgreSQLDialect{}.ToSQL(From(TableName("posts")).Where(
+		In(Column("tag"), Named("tags", []byte("a")), Named("tags", []byte("a"))),
+	))
+	assert.NoError(t, err)
+
+	var out bytes.Buffer
+	sw := &StreamWriter{W: &out, D: PostgreSQLDialect{}, Inline: true}
+	assert.NoError(t, sw.WriteStatement(Insert(TableName("users"), []Column{"name"}, InsertValuesStmt{{Named("name", "alice")}})))
+	assert.NoError(t, sw.WriteStatement(Insert(TableName("users"), []Column{"name"}, InsertValuesStmt{{Named("name", "bob")}})))
+	assert.True(t, errors.Is(sw.WriteStatement(s), ErrInvalidStatement))
+}
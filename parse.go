@@ -0,0 +1,1372 @@
+package sqb
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ParseError is syntax error or construct which can not be represented by sqb nodes.
+type ParseError struct {
+	// Pos is byte offset of offending sqlToken in SQL.
+	Pos int
+	Msg string
+}
+
+func (e *ParseError) Error() string {
+	return "sqb: parse error at position " + strconv.Itoa(e.Pos) + ": " + e.Msg
+}
+
+// Parse parses SELECT, INSERT, UPDATE or DELETE statement into SelectStmt, InsertStmt,
+// UpdateStmt or DeleteStmt, so that it renders back into equivalent SQL.
+// Literals are kept as RawSQL, ?, $N and ?NNN placeholders become Arg with values
+// taken from args, numbered placeholder used more than once becomes NamedArg named pN.
+// When args are not given, placeholders have nil value.
+func Parse(sql string, args ...interface{}) (SQB, error) {
+	p, err := newParser(sql, args)
+	if err != nil {
+		return nil, err
+	}
+
+	var s SQB
+	switch {
+	case p.isKeyword("SELECT"):
+		s, err = p.parseSelect()
+	case p.isKeyword("INSERT"), p.isKeyword("REPLACE"):
+		s, err = p.parseInsert()
+	case p.isKeyword("UPDATE"):
+		s, err = p.parseUpdate()
+	case p.isKeyword("DELETE"):
+		s, err = p.parseDelete()
+	default:
+		return nil, p.errorf("expected SELECT, INSERT, UPDATE or DELETE")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	p.accept(";")
+	if p.peek().kind != tokEOF {
+		return nil, p.errorf("unexpected " + p.peek().String())
+	}
+	return s, nil
+}
+
+// ParseSelect is Parse of SELECT statement.
+func ParseSelect(sql string, args ...interface{}) (SelectStmt, error) {
+	s, err := Parse(sql, args...)
+	if err != nil {
+		return SelectStmt{}, err
+	}
+	ss, ok := s.(SelectStmt)
+	if !ok {
+		return SelectStmt{}, &ParseError{Msg: "expected SELECT, got " + nodeName(s)}
+	}
+	return ss, nil
+}
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokName
+	tokNumber
+	tokString
+	tokPlaceholder
+	tokSymbol
+)
+
+type sqlToken struct {
+	kind tokenKind
+	text string
+	pos  int
+	// plain is name without quotes and dots, which can be keyword.
+	plain bool
+}
+
+func (t sqlToken) String() string {
+	if t.kind == tokEOF {
+		return "end of statement"
+	}
+	return strconv.Quote(t.text)
+}
+
+var symbols = []string{"<=", ">=", "<>", "!=", "||", "::", "=", "<", ">", "(", ")", ",", ";", "*", "+", "-", "/", "%"}
+
+func tokenize(sql string) ([]sqlToken, error) {
+	var toks []sqlToken
+	i := 0
+	for i < len(sql) {
+		r, size := utf8.DecodeRuneInString(sql[i:])
+		switch {
+		case unicode.IsSpace(r):
+			i += size
+		case strings.HasPrefix(sql[i:], "--"):
+			end := strings.IndexByte(sql[i:], '\n')
+			if end < 0 {
+				end = len(sql) - i
+			}
+			i += end
+		case strings.HasPrefix(sql[i:], "/*"):
+			end := strings.Index(sql[i+2:], "*/")
+			if end < 0 {
+				return nil, &ParseError{Pos: i, Msg: "unterminated comment"}
+			}
+			i += end + 4
+		case isNameStart(r), r == '"', r == '`':
+			end, plain, err := scanName(sql, i)
+			if err != nil {
+				return nil, err
+			}
+			toks = append(toks, sqlToken{kind: tokName, text: sql[i:end], pos: i, plain: plain})
+			i = end
+		case r >= '0' && r <= '9', r == '.' && i+1 < len(sql) && isDigit(sql[i+1]):
+			end := scanNumber(sql, i)
+			toks = append(toks, sqlToken{kind: tokNumber, text: sql[i:end], pos: i})
+			i = end
+		case r == '\'':
+			end, err := scanQuoted(sql, i, '\'')
+			if err != nil {
+				return nil, err
+			}
+			toks = append(toks, sqlToken{kind: tokString, text: sql[i:end], pos: i})
+			i = end
+		case r == '?', r == '$' && i+1 < len(sql) && isDigit(sql[i+1]):
+			end := i + 1
+			for end < len(sql) && isDigit(sql[end]) {
+				end++
+			}
+			toks = append(toks, sqlToken{kind: tokPlaceholder, text: sql[i:end], pos: i})
+			i = end
+		default:
+			sym := ""
+			for _, s := range symbols {
+				if strings.HasPrefix(sql[i:], s) {
+					sym = s
+					break
+				}
+			}
+			if sym == "" {
+				return nil, &ParseError{Pos: i, Msg: "unexpected character " + strconv.QuoteRune(r)}
+			}
+			toks = append(toks, sqlToken{kind: tokSymbol, text: sym, pos: i})
+			i += len(sym)
+		}
+	}
+	return append(toks, sqlToken{kind: tokEOF, pos: len(sql)}), nil
+}
+
+func isNameStart(r rune) bool {
+	return r == '_' || unicode.IsLetter(r)
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
+// scanName scans dotted name like u.id, "User".* or `t`.`c`.
+func scanName(sql string, i int) (end int, plain bool, err error) {
+	plain = true
+	for {
+		r, size := utf8.DecodeRuneInString(sql[i:])
+		switch {
+		case r == '"' || r == '`':
+			i, err = scanQuoted(sql, i, byte(r))
+			if err != nil {
+				return 0, false, err
+			}
+			plain = false
+		case r == '*' && !plain:
+			return i + 1, false, nil
+		case isNameStart(r):
+			for i < len(sql) {
+				r, size = utf8.DecodeRuneInString(sql[i:])
+				if !isNameStart(r) && !unicode.IsDigit(r) && r != '$' {
+					break
+				}
+				i += size
+			}
+		default:
+			return i, plain, nil
+		}
+
+		if i >= len(sql) || sql[i] != '.' {
+			return i, plain, nil
+		}
+		i++
+		plain = false
+	}
+}
+
+func scanNumber(sql string, i int) int {
+	for i < len(sql) && (isDigit(sql[i]) || sql[i] == '.') {
+		i++
+	}
+	if i < len(sql) && (sql[i] == 'e' || sql[i] == 'E') {
+		j := i + 1
+		if j < len(sql) && (sql[j] == '+' || sql[j] == '-') {
+			j++
+		}
+		if j < len(sql) && isDigit(sql[j]) {
+			i = j
+			for i < len(sql) && isDigit(sql[i]) {
+				i++
+			}
+		}
+	}
+	return i
+}
+
+// scanQuoted scans string quoted by q where q is escaped by doubling.
+func scanQuoted(sql string, i int, q byte) (int, error) {
+	start := i
+	i++
+	for i < len(sql) {
+		if sql[i] == q {
+			if i+1 < len(sql) && sql[i+1] == q {
+				i += 2
+				continue
+			}
+			return i + 1, nil
+		}
+		i++
+	}
+	return 0, &ParseError{Pos: start, Msg: "unterminated quoted string"}
+}
+
+// reserved are keywords which can not be alias without AS.
+var reserved = map[string]bool{
+	"SELECT": true, "FROM": true, "WHERE": true, "GROUP": true, "ORDER": true, "HAVING": true,
+	"LIMIT": true, "OFFSET": true, "FOR": true, "JOIN": true, "INNER": true, "LEFT": true,
+	"RIGHT": true, "FULL": true, "CROSS": true, "OUTER": true, "ON": true, "SET": true,
+	"RETURNING": true, "UNION": true, "AS": true, "VALUES": true, "AND": true, "OR": true,
+	"NOT": true, "IN": true, "IS": true, "LIKE": true, "ILIKE": true, "WHEN": true,
+	"THEN": true, "ELSE": true, "END": true, "DEFAULT": true,
+}
+
+var aggregates = map[string]bool{"COUNT": true, "MAX": true, "MIN": true, "SUM": true, "AVG": true}
+
+type sqlParser struct {
+	sql  string
+	toks []sqlToken
+	pos  int
+
+	args    []interface{}
+	hasArgs bool
+	nextArg int
+	// reused are numbered placeholders occurring more than once.
+	reused map[string]bool
+}
+
+func newParser(sql string, args []interface{}) (*sqlParser, error) {
+	toks, err := tokenize(sql)
+	if err != nil {
+		return nil, err
+	}
+	p := &sqlParser{sql: sql, toks: toks, args: args, hasArgs: len(args) > 0}
+	seen := make(map[string]bool)
+	for _, t := range toks {
+		if t.kind != tokPlaceholder || len(t.text) == 1 {
+			continue
+		}
+		if seen[t.text] {
+			if p.reused == nil {
+				p.reused = make(map[string]bool)
+			}
+			p.reused[t.text] = true
+		}
+		seen[t.text] = true
+	}
+	return p, nil
+}
+
+func (p *sqlParser) peek() sqlToken {
+	return p.toks[p.pos]
+}
+
+func (p *sqlParser) peekAt(n int) sqlToken {
+	if p.pos+n >= len(p.toks) {
+		return p.toks[len(p.toks)-1]
+	}
+	return p.toks[p.pos+n]
+}
+
+func (p *sqlParser) errorf(msg string) error {
+	return &ParseError{Pos: p.peek().pos, Msg: msg}
+}
+
+func isKeyword(t sqlToken, kw string) bool {
+	return t.kind == tokName && t.plain && strings.EqualFold(t.text, kw)
+}
+
+func (p *sqlParser) isKeyword(kw string) bool {
+	return isKeyword(p.peek(), kw)
+}
+
+// acceptKeywords consumes sequence of keywords if all of them follow.
+func (p *sqlParser) acceptKeywords(kws ...string) bool {
+	for i, kw := range kws {
+		if !isKeyword(p.peekAt(i), kw) {
+			return false
+		}
+	}
+	p.pos += len(kws)
+	return true
+}
+
+func (p *sqlParser) expectKeywords(kws ...string) error {
+	if !p.acceptKeywords(kws...) {
+		return p.errorf("expected " + strings.Join(kws, " ") + ", got " + p.peek().String())
+	}
+	return nil
+}
+
+func (p *sqlParser) isSymbol(s string) bool {
+	t := p.peek()
+	return t.kind == tokSymbol && t.text == s
+}
+
+func (p *sqlParser) accept(s string) bool {
+	if p.isSymbol(s) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *sqlParser) expect(s string) error {
+	if !p.accept(s) {
+		return p.errorf("expected " + strconv.Quote(s) + ", got " + p.peek().String())
+	}
+	return nil
+}
+
+func (p *sqlParser) parseName() (string, error) {
+	t := p.peek()
+	if t.kind != tokName || (t.plain && reserved[strings.ToUpper(t.text)]) {
+		return "", p.errorf("expected name, got " + t.String())
+	}
+	p.pos++
+	return unquoteName(t.text), nil
+}
+
+// unquoteName removes quotes from parts of dotted name which are the same unquoted,
+// so that dialect quoting identifiers does not quote them twice.
+// Double quoted names with upper case letters are kept as case sensitive.
+func unquoteName(name string) string {
+	if !strings.ContainsAny(name, "\"`") {
+		return name
+	}
+	var sb strings.Builder
+	for i := 0; i < len(name); {
+		q := name[i]
+		if q != '"' && q != '`' {
+			end := strings.IndexByte(name[i:], '.')
+			if end < 0 {
+				sb.WriteString(name[i:])
+				break
+			}
+			sb.WriteString(name[i : i+end+1])
+			i += end + 1
+			continue
+		}
+
+		end, _ := scanQuoted(name, i, q)
+		part := name[i:end]
+		inner := part[1 : len(part)-1]
+		if isPlainIdent(inner) && !reserved[strings.ToUpper(inner)] && (q == '`' || inner == strings.ToLower(inner)) {
+			part = inner
+		}
+		sb.WriteString(part)
+		i = end
+		if i < len(name) {
+			sb.WriteByte('.')
+			i++
+		}
+	}
+	return sb.String()
+}
+
+// parseAlias parses optional [AS] alias, quoted alias is unquoted.
+func (p *sqlParser) parseAlias() (string, error) {
+	hasAS := p.acceptKeywords("AS")
+	t := p.peek()
+	if t.kind != tokName || (t.plain && reserved[strings.ToUpper(t.text)]) || p.isIndexHint() {
+		if hasAS {
+			return "", p.errorf("expected alias, got " + t.String())
+		}
+		return "", nil
+	}
+	p.pos++
+	if t.text[0] == '"' || t.text[0] == '`' {
+		if strings.ContainsAny(t.text[1:len(t.text)-1], string(t.text[0])) {
+			q := string(t.text[0])
+			return strings.Replace(t.text[1:len(t.text)-1], q+q, q, -1), nil
+		}
+		return t.text[1 : len(t.text)-1], nil
+	}
+	return t.text, nil
+}
+
+func (p *sqlParser) parseSelect() (SelectStmt, error) {
+	var s SelectStmt
+	err := p.expectKeywords("SELECT")
+	if err != nil {
+		return s, err
+	}
+
+	s.IsDistinct = p.acceptKeywords("DISTINCT")
+	if !p.accept("*") {
+		cols, err := p.parseSelectList()
+		if err != nil {
+			return s, err
+		}
+		s.Cols = NewColumnList(cols...)
+	}
+
+	err = p.expectKeywords("FROM")
+	if err != nil {
+		return s, err
+	}
+	s.From, err = p.parseFrom()
+	if err != nil {
+		return s, err
+	}
+
+	if p.acceptKeywords("WHERE") {
+		s.WhereStmt, err = p.parseWhere()
+		if err != nil {
+			return s, err
+		}
+	}
+
+	if p.acceptKeywords("GROUP", "BY") {
+		for {
+			c, err := p.parseCol()
+			if err != nil {
+				return s, err
+			}
+			s.GroupByStmt.Cols = append(s.GroupByStmt.Cols, c)
+			if !p.accept(",") {
+				break
+			}
+		}
+	}
+
+	if p.acceptKeywords("ORDER", "BY") {
+		for {
+			c, err := p.parseCol()
+			if err != nil {
+				return s, err
+			}
+			elem := Asc(c)
+			if p.acceptKeywords("DESC") {
+				elem = Desc(c)
+			} else {
+				p.acceptKeywords("ASC")
+			}
+			s.OrderByStmt.Elems = append(s.OrderByStmt.Elems, elem)
+			if !p.accept(",") {
+				break
+			}
+		}
+	}
+
+	if p.acceptKeywords("LIMIT") {
+		t := p.peek()
+		n, err := p.parseUint()
+		if err != nil {
+			return s, err
+		}
+		if p.accept(",") {
+			s.OffsetStmt.V = n
+			t = p.peek()
+			n, err = p.parseUint()
+			if err != nil {
+				return s, err
+			}
+		}
+		// LimitStmt renders nothing for 0
+		if n == 0 {
+			return s, &ParseError{Pos: t.pos, Msg: "LIMIT 0 is not supported"}
+		}
+		s.LimitStmt.V = n
+	}
+
+	if p.acceptKeywords("OFFSET") {
+		s.OffsetStmt.V, err = p.parseUint()
+		if err != nil {
+			return s, err
+		}
+	}
+
+	s.IsForUpdate = p.acceptKeywords("FOR", "UPDATE")
+	return s, nil
+}
+
+func (p *sqlParser) parseUint() (uint64, error) {
+	t := p.peek()
+	if t.kind != tokNumber {
+		return 0, p.errorf("expected number, got " + t.String())
+	}
+	n, err := strconv.ParseUint(t.text, 10, 64)
+	if err != nil {
+		return 0, p.errorf("expected integer, got " + t.String())
+	}
+	p.pos++
+	return n, nil
+}
+
+func (p *sqlParser) parseSelectList() ([]Col, error) {
+	var cols []Col
+	for {
+		c, err := p.parseCol()
+		if err != nil {
+			return nil, err
+		}
+
+		alias, err := p.parseAlias()
+		if err != nil {
+			return nil, err
+		}
+		if alias != "" {
+			c = ColumnAlias{C: c, AS: alias}
+		}
+
+		cols = append(cols, c)
+		if !p.accept(",") {
+			return cols, nil
+		}
+	}
+}
+
+func (p *sqlParser) parseCol() (Col, error) {
+	t := p.peek()
+	n, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	c, ok := n.(Col)
+	if !ok {
+		return nil, &ParseError{Pos: t.pos, Msg: nodeName(n) + " can not be used as column"}
+	}
+	return c, nil
+}
+
+func (p *sqlParser) parseFrom() (Table, error) {
+	left, err := p.parseTableRef()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		var kind string
+		switch {
+		case p.accept(","), p.acceptKeywords("CROSS", "JOIN"):
+			kind = "CROSS"
+		case p.acceptKeywords("JOIN"), p.acceptKeywords("INNER", "JOIN"):
+			kind = "INNER"
+		case p.acceptKeywords("LEFT", "JOIN"), p.acceptKeywords("LEFT", "OUTER", "JOIN"):
+			kind = "LEFT"
+		case p.acceptKeywords("RIGHT", "JOIN"), p.acceptKeywords("RIGHT", "OUTER", "JOIN"):
+			kind = "RIGHT"
+		case p.acceptKeywords("FULL", "JOIN"), p.acceptKeywords("FULL", "OUTER", "JOIN"):
+			kind = "FULL OUTER"
+		default:
+			return left.(Table), nil
+		}
+
+		right, err := p.parseTableRef()
+		if err != nil {
+			return nil, err
+		}
+
+		if kind == "CROSS" {
+			left = CrossJoin(left, right)
+			continue
+		}
+
+		err = p.expectKeywords("ON")
+		if err != nil {
+			return nil, err
+		}
+		on, err := p.parseOn()
+		if err != nil {
+			return nil, err
+		}
+
+		switch kind {
+		case "INNER":
+			left = InnerJoin(left, right, on)
+		case "LEFT":
+			left = LeftJoin(left, right, on)
+		case "RIGHT":
+			left = RightJoin(left, right, on)
+		default:
+			left = FullOuterJoin(left, right, on)
+		}
+	}
+}
+
+func (p *sqlParser) parseTableRef() (Joinable, error) {
+	if !p.accept("(") {
+		name, err := p.parseName()
+		if err != nil {
+			return nil, err
+		}
+		alias, err := p.parseAlias()
+		if err != nil {
+			return nil, err
+		}
+		if alias != "" {
+			return p.parseIndexHints(TableName(name).As(alias))
+		}
+		return p.parseIndexHints(TableName(name))
+	}
+
+	if p.acceptKeywords("VALUES") {
+		values, err := p.parseValues()
+		if err != nil {
+			return nil, err
+		}
+		err = p.expect(")")
+		if err != nil {
+			return nil, err
+		}
+		vt := ValuesTable{Values: values}
+		vt.AS, err = p.parseAlias()
+		if err != nil {
+			return nil, err
+		}
+		if vt.AS == "" {
+			return nil, p.errorf("expected alias of VALUES, got " + p.peek().String())
+		}
+		if p.accept("(") {
+			vt.Columns, err = p.parseColumnNames()
+			if err != nil {
+				return nil, err
+			}
+		}
+		return vt, nil
+	}
+
+	s, err := p.parseSelect()
+	if err != nil {
+		return nil, err
+	}
+	err = p.expect(")")
+	if err != nil {
+		return nil, err
+	}
+	alias, err := p.parseAlias()
+	if err != nil {
+		return nil, err
+	}
+	if alias == "" {
+		return nil, p.errorf("expected alias of subquery, got " + p.peek().String())
+	}
+	return s.As(alias), nil
+}
+
+// parseColumnNames parses names up to closing parenthesis.
+var indexHints = []IndexHintKind{HintUse, HintForce, HintIgnore}
+
+func (p *sqlParser) isIndexHint() bool {
+	for _, kind := range indexHints {
+		if p.isKeyword(string(kind)) && isKeyword(p.peekAt(1), "INDEX") {
+			return true
+		}
+	}
+	return false
+}
+
+// parseIndexHints parses MySQL index hints following table.
+func (p *sqlParser) parseIndexHints(t Joinable) (Joinable, error) {
+	ht := HintedTable{Table: t}
+	for p.isIndexHint() {
+		kind := IndexHintKind(strings.ToUpper(p.peek().text))
+		p.pos += 2
+		err := p.expect("(")
+		if err != nil {
+			return nil, err
+		}
+		cols, err := p.parseColumnNames()
+		if err != nil {
+			return nil, err
+		}
+		hint := IndexHint{Kind: kind, Indexes: make([]string, 0, len(cols))}
+		for _, c := range cols {
+			hint.Indexes = append(hint.Indexes, string(c))
+		}
+		ht.Hints = append(ht.Hints, hint)
+	}
+	if len(ht.Hints) == 0 {
+		return t, nil
+	}
+	return ht, nil
+}
+
+func (p *sqlParser) parseColumnNames() ([]Column, error) {
+	var cols []Column
+	for {
+		name, err := p.parseName()
+		if err != nil {
+			return nil, err
+		}
+		cols = append(cols, Column(name))
+		if !p.accept(",") {
+			return cols, p.expect(")")
+		}
+	}
+}
+
+func (p *sqlParser) parseValues() (InsertValuesStmt, error) {
+	var rows InsertValuesStmt
+	for {
+		// row constructor of MySQL
+		if p.isKeyword("ROW") && isSymbol(p.peekAt(1), "(") {
+			p.pos++
+		}
+		err := p.expect("(")
+		if err != nil {
+			return nil, err
+		}
+
+		var row []InsertValue
+		for {
+			t := p.peek()
+			n, err := p.parseOperand()
+			if err != nil {
+				return nil, err
+			}
+			v, ok := n.(InsertValue)
+			if !ok {
+				return nil, &ParseError{Pos: t.pos, Msg: nodeName(n) + " can not be used as VALUES element"}
+			}
+			row = append(row, v)
+			if !p.accept(",") {
+				break
+			}
+		}
+
+		err = p.expect(")")
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+		if !p.accept(",") {
+			return rows, nil
+		}
+	}
+}
+
+func (p *sqlParser) parseWhere() (WhereStmt, error) {
+	exprs, err := p.parseAndTerms()
+	if err != nil {
+		return WhereStmt{}, err
+	}
+	if p.isKeyword("OR") {
+		e, err := p.parseOrTail(andOf(exprs))
+		if err != nil {
+			return WhereStmt{}, err
+		}
+		exprs = []BoolExpr{e}
+	}
+	return WhereStmt{Exprs: exprs}, nil
+}
+
+func (p *sqlParser) parseOn() (OnExpr, error) {
+	e, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if on, ok := e.(OnExpr); ok {
+		return on, nil
+	}
+	return OnBool(e), nil
+}
+
+func (p *sqlParser) parseExpr() (BoolExpr, error) {
+	exprs, err := p.parseAndTerms()
+	if err != nil {
+		return nil, err
+	}
+	return p.parseOrTail(andOf(exprs))
+}
+
+func (p *sqlParser) parseOrTail(first BoolExpr) (BoolExpr, error) {
+	exprs := []BoolExpr{first}
+	for p.acceptKeywords("OR") {
+		terms, err := p.parseAndTerms()
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, andOf(terms))
+	}
+	if len(exprs) == 1 {
+		return first, nil
+	}
+	return Or(exprs...), nil
+}
+
+func andOf(exprs []BoolExpr) BoolExpr {
+	if len(exprs) == 1 {
+		return exprs[0]
+	}
+	return And(exprs...)
+}
+
+func (p *sqlParser) parseAndTerms() ([]BoolExpr, error) {
+	var exprs []BoolExpr
+	for {
+		e, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, e)
+		if !p.acceptKeywords("AND") {
+			return exprs, nil
+		}
+	}
+}
+
+func (p *sqlParser) parseNot() (BoolExpr, error) {
+	if p.acceptKeywords("NOT") {
+		e, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return Not(e), nil
+	}
+
+	if p.acceptKeywords("EXISTS") {
+		err := p.expect("(")
+		if err != nil {
+			return nil, err
+		}
+		s, err := p.parseSelect()
+		if err != nil {
+			return nil, err
+		}
+		return ExistsStmt{Select: s}, p.expect(")")
+	}
+
+	if p.isSymbol("(") {
+		// one level of parentheses belongs to enclosing AND, OR, NOT or WHERE,
+		// second one is And or Or of single expression
+		double := isSymbol(p.peekAt(1), "(") && p.closing(p.pos+1)+1 == p.closing(p.pos)
+		p.pos++
+		var e BoolExpr
+		var err error
+		if double {
+			e, err = p.parseNot()
+			e = And(e)
+		} else {
+			e, err = p.parseExpr()
+		}
+		if err != nil {
+			return nil, err
+		}
+		return e, p.expect(")")
+	}
+	return p.parsePredicate()
+}
+
+// closing returns index of token closing parenthesis opened at i.
+func (p *sqlParser) closing(i int) int {
+	depth := 0
+	for ; i < len(p.toks); i++ {
+		switch {
+		case isSymbol(p.toks[i], "("):
+			depth++
+		case isSymbol(p.toks[i], ")"):
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+var comparisons = map[string]bool{"<>": true, "!=": true, "<": true, ">": true, "<=": true, ">=": true}
+
+func (p *sqlParser) parsePredicate() (BoolExpr, error) {
+	left, err := p.parseComparable()
+	if err != nil {
+		return nil, err
+	}
+
+	t := p.peek()
+	switch {
+	case t.kind == tokSymbol && t.text == "=":
+		p.pos++
+		right, err := p.parseComparable()
+		if err != nil {
+			return nil, err
+		}
+		return Eq(left, right), nil
+	case t.kind == tokSymbol && comparisons[t.text]:
+		p.pos++
+		right, err := p.parseComparable()
+		if err != nil {
+			return nil, err
+		}
+		return BinaryOp(left, t.text, right), nil
+	case isKeyword(t, "LIKE"), isKeyword(t, "ILIKE"):
+		p.pos++
+		right, err := p.parseComparable()
+		if err != nil {
+			return nil, err
+		}
+		return BinaryOp(left, strings.ToUpper(t.text), right), nil
+	case p.acceptKeywords("IS", "NULL"):
+		return NullCheck{A: left, IsNull: true}, nil
+	case p.acceptKeywords("IS", "NOT", "NULL"):
+		return NullCheck{A: left}, nil
+	case p.acceptKeywords("IN"):
+		return p.parseIn(left)
+	case p.acceptKeywords("NOT", "IN"):
+		in, err := p.parseIn(left)
+		if err != nil {
+			return nil, err
+		}
+		return Not(in), nil
+	}
+	return left, nil
+}
+
+func (p *sqlParser) parseIn(left Comparable) (BoolExpr, error) {
+	err := p.expect("(")
+	if err != nil {
+		return nil, err
+	}
+	if p.isKeyword("SELECT") {
+		return nil, p.errorf("IN subquery is not supported")
+	}
+
+	var list []Comparable
+	for {
+		c, err := p.parseComparable()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, c)
+		if !p.accept(",") {
+			break
+		}
+	}
+	return In(left, list...), p.expect(")")
+}
+
+func (p *sqlParser) parseComparable() (Comparable, error) {
+	t := p.peek()
+	n, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+	c, ok := n.(Comparable)
+	if !ok {
+		return nil, &ParseError{Pos: t.pos, Msg: nodeName(n) + " can not be compared"}
+	}
+	return c, nil
+}
+
+// parseOperand parses value: column, placeholder, literal, aggregate, CASE or CAST.
+func (p *sqlParser) parseOperand() (SQB, error) {
+	t := p.peek()
+	switch t.kind {
+	case tokPlaceholder:
+		p.pos++
+		return p.arg(t)
+	case tokNumber, tokString:
+		p.pos++
+		return Raw(t.text), nil
+	case tokSymbol:
+		if t.text == "*" {
+			p.pos++
+			return Column("*"), nil
+		}
+		if t.text == "-" && p.peekAt(1).kind == tokNumber {
+			num := p.peekAt(1).text
+			p.pos += 2
+			return Raw("-" + num), nil
+		}
+		return nil, p.errorf("unexpected " + t.String())
+	case tokEOF:
+		return nil, p.errorf("unexpected " + t.String())
+	}
+
+	switch {
+	case isKeyword(t, "NULL"), isKeyword(t, "TRUE"), isKeyword(t, "FALSE"):
+		p.pos++
+		return Raw(strings.ToUpper(t.text)), nil
+	case isKeyword(t, "DEFAULT"):
+		p.pos++
+		return Default, nil
+	case isKeyword(t, "CASE"):
+		p.pos++
+		return p.parseCase()
+	case isKeyword(t, "CAST") && isSymbol(p.peekAt(1), "("):
+		p.pos += 2
+		return p.parseCast()
+	}
+
+	name, err := p.parseName()
+	if err != nil {
+		return nil, err
+	}
+	if !p.accept("(") {
+		return Column(name), nil
+	}
+	if !t.plain || !aggregates[strings.ToUpper(name)] {
+		return nil, &ParseError{Pos: t.pos, Msg: "function " + name + " is not supported"}
+	}
+
+	fc := AggrFuncCall{Name: name}
+	fc.IsDistinct = p.acceptKeywords("DISTINCT")
+	if p.accept(")") {
+		return fc, nil
+	}
+	for {
+		arg, err := p.parseOperand()
+		if err != nil {
+			return nil, err
+		}
+		fc.Args = append(fc.Args, arg)
+		if !p.accept(",") {
+			break
+		}
+	}
+	return fc, p.expect(")")
+}
+
+func isSymbol(t sqlToken, s string) bool {
+	return t.kind == tokSymbol && t.text == s
+}
+
+func (p *sqlParser) arg(t sqlToken) (SQB, error) {
+	i := p.nextArg
+	if t.text == "?" {
+		p.nextArg++
+	} else {
+		n, err := strconv.Atoi(t.text[1:])
+		if err != nil || n == 0 {
+			return nil, &ParseError{Pos: t.pos, Msg: "invalid placeholder " + t.text}
+		}
+		i = n - 1
+	}
+
+	var v interface{}
+	if p.hasArgs {
+		if i >= len(p.args) {
+			return nil, &ParseError{Pos: t.pos, Msg: "no argument for placeholder " + t.text}
+		}
+		v = p.args[i]
+	}
+	if p.reused[t.text] {
+		return NamedArg{Name: "p" + t.text[1:], V: v}, nil
+	}
+	return Arg{V: v}, nil
+}
+
+func (p *sqlParser) parseCase() (SQB, error) {
+	var ce CaseExpr
+	var err error
+	if !p.isKeyword("WHEN") {
+		ce.Operand, err = p.parseComparable()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for p.acceptKeywords("WHEN") {
+		var wc WhenClause
+		wc.When, err = p.parseComparable()
+		if err != nil {
+			return nil, err
+		}
+		err = p.expectKeywords("THEN")
+		if err != nil {
+			return nil, err
+		}
+		wc.Then, err = p.parseComparable()
+		if err != nil {
+			return nil, err
+		}
+		ce.Whens = append(ce.Whens, wc)
+	}
+	if len(ce.Whens) == 0 {
+		return nil, p.errorf("expected WHEN, got " + p.peek().String())
+	}
+
+	if p.acceptKeywords("ELSE") {
+		ce.Else, err = p.parseComparable()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return ce, p.expectKeywords("END")
+}
+
+func (p *sqlParser) parseCast() (SQB, error) {
+	a, err := p.parseComparable()
+	if err != nil {
+		return nil, err
+	}
+	err = p.expectKeywords("AS")
+	if err != nil {
+		return nil, err
+	}
+
+	// type is everything up to matching parenthesis, like varchar(10)
+	start := p.peek().pos
+	depth := 0
+	for {
+		t := p.peek()
+		if t.kind == tokEOF {
+			return nil, p.errorf("expected \")\", got " + t.String())
+		}
+		if isSymbol(t, "(") {
+			depth++
+		}
+		if isSymbol(t, ")") {
+			if depth == 0 {
+				break
+			}
+			depth--
+		}
+		p.pos++
+	}
+	typ := strings.TrimSpace(p.sql[start:p.peek().pos])
+	if typ == "" {
+		return nil, p.errorf("expected type, got " + p.peek().String())
+	}
+	p.pos++
+	return Cast(a, typ), nil
+}
+
+func (p *sqlParser) parseInsert() (SQB, error) {
+	var is InsertStmt
+	switch {
+	case p.acceptKeywords("REPLACE"), p.acceptKeywords("INSERT", "OR", "REPLACE"):
+		is.Modifier = InsertReplace
+	case p.acceptKeywords("INSERT", "IGNORE"), p.acceptKeywords("INSERT", "OR", "IGNORE"):
+		is.Modifier = InsertIgnore
+	default:
+		err := p.expectKeywords("INSERT")
+		if err != nil {
+			return nil, err
+		}
+	}
+	err := p.expectKeywords("INTO")
+	if err != nil {
+		return nil, err
+	}
+
+	name, err := p.parseName()
+	if err != nil {
+		return nil, err
+	}
+	is.Table = TableName(name)
+
+	if p.accept("(") {
+		is.Columns, err = p.parseColumnNames()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	switch {
+	case p.acceptKeywords("DEFAULT", "VALUES"):
+		is.Source = InsertValuesStmt{}
+	case p.acceptKeywords("VALUES"):
+		// VALUES () is DEFAULT VALUES of MySQL
+		if isSymbol(p.peek(), "(") && isSymbol(p.peekAt(1), ")") {
+			p.pos += 2
+			is.Source = InsertValuesStmt{}
+			break
+		}
+		is.Source, err = p.parseValues()
+	case p.isKeyword("SELECT"):
+		is.Source, err = p.parseSelect()
+	default:
+		return nil, p.errorf("expected VALUES or SELECT, got " + p.peek().String())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if p.acceptKeywords("ON", "CONFLICT") {
+		is.OnConflictStmt, err = p.parseOnConflict()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	is.ReturningStmt, err = p.parseReturning()
+	if err != nil {
+		return nil, err
+	}
+	return is, nil
+}
+
+func (p *sqlParser) parseOnConflict() (OnConflictStmt, error) {
+	var oc OnConflictStmt
+	var err error
+	if p.accept("(") {
+		oc.Target, err = p.parseColumnNames()
+		if err != nil {
+			return oc, err
+		}
+	}
+
+	err = p.expectKeywords("DO")
+	if err != nil {
+		return oc, err
+	}
+	if p.acceptKeywords("NOTHING") {
+		oc.DoNothing = true
+		return oc, nil
+	}
+	err = p.expectKeywords("UPDATE")
+	if err != nil {
+		return oc, err
+	}
+	oc.Set, err = p.parseSet()
+	if err != nil {
+		return oc, err
+	}
+	if p.acceptKeywords("WHERE") {
+		oc.WhereStmt, err = p.parseWhere()
+	}
+	return oc, err
+}
+
+func (p *sqlParser) parseReturning() (ReturningStmt, error) {
+	if !p.acceptKeywords("RETURNING") {
+		return ReturningStmt{}, nil
+	}
+	if p.accept("*") {
+		return ReturningStmt{Cols: NewColumnList()}, nil
+	}
+	cols, err := p.parseSelectList()
+	if err != nil {
+		return ReturningStmt{}, err
+	}
+	return ReturningStmt{Cols: NewColumnList(cols...)}, nil
+}
+
+func (p *sqlParser) parseUpdate() (SQB, error) {
+	err := p.expectKeywords("UPDATE")
+	if err != nil {
+		return nil, err
+	}
+
+	var us UpdateStmt
+	name, err := p.parseName()
+	if err != nil {
+		return nil, err
+	}
+	us.Table = TableName(name)
+
+	switch {
+	case p.acceptKeywords("CROSS", "JOIN"):
+		us.From.Table, err = p.parseTableRef()
+	case p.acceptKeywords("JOIN"), p.acceptKeywords("INNER", "JOIN"):
+		us.From.Table, err = p.parseTableRef()
+		if err == nil {
+			err = p.expectKeywords("ON")
+		}
+		if err == nil {
+			us.From.On, err = p.parseOn()
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	us.Set, err = p.parseSet()
+	if err != nil {
+		return nil, err
+	}
+
+	if us.From.Empty() && p.acceptKeywords("FROM") {
+		from, err := p.parseFrom()
+		if err != nil {
+			return nil, err
+		}
+		us.From.Table = from.(Joinable)
+	}
+
+	if p.acceptKeywords("WHERE") {
+		us.WhereStmt, err = p.parseWhere()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	us.ReturningStmt, err = p.parseReturning()
+	if err != nil {
+		return nil, err
+	}
+	return us, nil
+}
+
+func (p *sqlParser) parseSet() (SetStmt, error) {
+	err := p.expectKeywords("SET")
+	if err != nil {
+		return nil, err
+	}
+	var set SetStmt
+	for {
+		name, err := p.parseName()
+		if err != nil {
+			return nil, err
+		}
+		err = p.expect("=")
+		if err != nil {
+			return nil, err
+		}
+		v, err := p.parseCol()
+		if err != nil {
+			return nil, err
+		}
+		set = append(set, SetArg{Key: Column(name), Value: v})
+		if !p.accept(",") {
+			return set, nil
+		}
+	}
+}
+
+func (p *sqlParser) parseDelete() (SQB, error) {
+	err := p.expectKeywords("DELETE")
+	if err != nil {
+		return nil, err
+	}
+	err = p.expectKeywords("FROM")
+	if err != nil {
+		return nil, err
+	}
+
+	var ds DeleteStmt
+	name, err := p.parseName()
+	if err != nil {
+		return nil, err
+	}
+	ds.Table = TableName(name)
+
+	if p.acceptKeywords("WHERE") {
+		ds.WhereStmt, err = p.parseWhere()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ds.ReturningStmt, err = p.parseReturning()
+	if err != nil {
+		return nil, err
+	}
+	return ds, nil
+}
@@ -0,0 +1,220 @@
+package sqb
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// assertRoundTrip checks that statement rendered by dialect parses back
+// into tree which renders the same SQL and args.
+func assertRoundTrip(t *testing.T, d Dialect, s SQB) {
+	t.Helper()
+	switch s.(type) {
+	case SelectStmt, InsertStmt, UpdateStmt, BatchUpdateStmt, DeleteStmt:
+	default:
+		return
+	}
+
+	sql, args, err := d.ToSQL(s)
+	if err != nil {
+		return
+	}
+	parsed, err := Parse(sql, args...)
+	if !assert.NoError(t, err, sql) {
+		return
+	}
+	parsedSQL, parsedArgs, err := d.ToSQL(parsed)
+	if assert.NoError(t, err, sql) {
+		assert.Equal(t, sql, parsedSQL)
+		assert.Equal(t, args, parsedArgs)
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		sql      string
+		args     []interface{}
+		expected SQB
+	}{
+		{
+			name: "select",
+			sql:  "select distinct u.id, u.name as n, count(*) from users u left outer join posts as p on p.user_id = u.id where u.id = ? and (u.name like ? or u.name is null) group by u.id, u.name order by u.id desc, n limit 10 offset 20",
+			args: []interface{}{1, "a%"},
+			expected: From(LeftJoin(TableName("users").As("u"), TableName("posts").As("p"), Eq(Column("p.user_id"), Column("u.id")))).
+				Distinct().
+				Select(Column("u.id"), Column("u.name").As("n"), AggrFuncCall{Name: "count", Args: []SQB{Column("*")}}).
+				Where(Eq(Column("u.id"), Arg{V: 1}), Or(BinaryOp(Column("u.name"), "LIKE", Arg{V: "a%"}), NullCheck{A: Column("u.name"), IsNull: true})).
+				GroupBy(Column("u.id"), Column("u.name")).
+				OrderBy(Desc(Column("u.id")), Asc(Column("n"))).
+				Limit(10).Offset(20),
+		},
+		{
+			name: "postgresql placeholders and literals",
+			sql:  "SELECT * FROM users WHERE id IN ($2, $1, 3) AND name <> 'it''s' AND NOT (active = TRUE)",
+			args: []interface{}{"a", "b"},
+			expected: From(TableName("users")).Where(
+				In(Column("id"), Arg{V: "b"}, Arg{V: "a"}, Raw("3")),
+				BinaryOp(Column("name"), "<>", Raw("'it''s'")),
+				Not(Eq(Column("active"), Raw("TRUE"))),
+			),
+		},
+		{
+			name:     "mysql limit",
+			sql:      "SELECT `id` FROM `users` LIMIT 5, 10 FOR UPDATE;",
+			expected: From(TableName("users")).Select(Column("id")).Limit(10).Offset(5).ForUpdate(),
+		},
+		{
+			name: "quoted names",
+			sql:  "SELECT `u`.`order`, \"Users\".\"id\", u.\"name\" FROM `users` AS u, \"Users\" WHERE `u`.`na``me` = ?3",
+			args: []interface{}{1, 2, 3},
+			expected: From(CrossJoin(TableName("users").As("u"), TableName(`"Users"`))).
+				Select(Column("u.`order`"), Column(`"Users".id`), Column("u.name")).
+				Where(Eq(Column("u.`na``me`"), Arg{V: 3})),
+		},
+		{
+			name: "reused numbered placeholders",
+			sql:  "SELECT * FROM users WHERE (id=?1) AND ((owner_id=?2) OR (editor_id=?2))",
+			args: []interface{}{1, 2},
+			expected: From(TableName("users")).Where(
+				Eq(Column("id"), Arg{V: 1}),
+				Or(Eq(Column("owner_id"), Named("p2", 2)), Eq(Column("editor_id"), Named("p2", 2))),
+			),
+		},
+		{
+			name: "placeholders without args",
+			sql:  "SELECT * FROM users WHERE id = ?",
+			expected: From(TableName("users")).Where(
+				Eq(Column("id"), Arg{}),
+			),
+		},
+		{
+			name:     "insert",
+			sql:      "INSERT INTO users (id, name) VALUES (?, DEFAULT), (2, CAST(? AS varchar(10))) RETURNING id",
+			args:     []interface{}{1, "b"},
+			expected: Insert(TableName("users"), []Column{"id", "name"}, InsertValuesStmt{{Arg{V: 1}, Default}, {Raw("2"), Cast(Arg{V: "b"}, "varchar(10)")}}).Returning(Column("id")),
+		},
+		{
+			name: "update",
+			sql:  "UPDATE users SET name = ?, visits = CASE id WHEN 1 THEN 2 ELSE visits END FROM cities AS c WHERE c.id = users.city_id",
+			args: []interface{}{"a"},
+			expected: UpdateStmt{
+				Table: TableName("users"),
+				Set: SetStmt{
+					{Key: Column("name"), Value: Arg{V: "a"}},
+					{Key: Column("visits"), Value: CaseExpr{Operand: Column("id"), Whens: []WhenClause{{When: Raw("1"), Then: Raw("2")}}, Else: Column("visits")}},
+				},
+				From:      UpdateFromStmt{Table: TableName("cities").As("c")},
+				WhereStmt: WhereStmt{Exprs: []BoolExpr{Eq(Column("c.id"), Column("users.city_id"))}},
+			},
+		},
+		{
+			name: "mysql update join",
+			sql:  "UPDATE users INNER JOIN cities ON cities.id=users.city_id SET users.region = cities.region",
+			expected: UpdateStmt{
+				Table: TableName("users"),
+				Set:   SetStmt{{Key: Column("users.region"), Value: Column("cities.region")}},
+				From:  UpdateFromStmt{Table: TableName("cities"), On: Eq(Column("cities.id"), Column("users.city_id"))},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := Parse(tt.sql, tt.args...)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.expected, s)
+			}
+		})
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		sql  string
+		args []interface{}
+		pos  int
+	}{
+		{name: "truncate", sql: "TRUNCATE users", pos: 0},
+		{name: "delete without from", sql: "DELETE users", pos: 7},
+		{name: "no from", sql: "SELECT 1", pos: 8},
+		{name: "function", sql: "SELECT lower(name) FROM users", pos: 7},
+		{name: "subquery without alias", sql: "SELECT * FROM (SELECT * FROM users)", pos: 35},
+		{name: "missing argument", sql: "SELECT * FROM users WHERE id = $2", args: []interface{}{1}, pos: 31},
+		{name: "unterminated string", sql: "SELECT * FROM users WHERE name = 'a", pos: 33},
+		{name: "trailing garbage", sql: "SELECT * FROM users users2 users3", pos: 27},
+		{name: "limit 0", sql: "SELECT * FROM users LIMIT 0", pos: 26},
+		{name: "mysql limit 0", sql: "SELECT * FROM users LIMIT 5, 0", pos: 29},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.sql, tt.args...)
+			var pe *ParseError
+			if assert.True(t, errors.As(err, &pe), "unexpected error %v", err) {
+				assert.Equal(t, tt.pos, pe.Pos, pe.Error())
+			}
+		})
+	}
+}
+
+func TestParse_RoundTrip(t *testing.T) {
+	users := TableName("users")
+	tests := []struct {
+		name    string
+		dialect Dialect
+		sqb     SQB
+	}{
+		{
+			name:    "subqueries",
+			dialect: PostgreSQLDialect{},
+			sqb: From(JB(users.As("u")).InnerJoin(From(TableName("posts")).Select(Column("user_id"), ColumnAlias{C: Max(Column("id")), AS: "last_id"}).GroupBy(Column("user_id")).As("p"), Eq(Column("p.user_id"), Column("u.id"))).CrossJoin(TableName("cities"), nil)).
+				Where(ExistsStmt{Select: From(TableName("bans")).Where(Eq(Column("bans.user_id"), Column("u.id")))}, Or(And(Eq(Column("a"), Arg{V: 1}), Eq(Column("b"), Arg{V: 2})), Not(Or(Eq(Column("c"), Arg{V: 3}))))),
+		},
+		{
+			name:    "values table",
+			dialect: DefaultDialect{},
+			sqb:     From(JB(users).LeftJoin(InsertValuesStmt{{Arg{V: 1}, Arg{V: "a"}}}.As("v", "id", "name"), OnAndExpr{Exprs: []OnExpr{Eq(Column("v.id"), Column("users.id")), In(Column("v.name"), Arg{V: "b"})}})),
+		},
+		{
+			name:    "struct columns",
+			dialect: DefaultDialect{},
+			sqb:     From(TableName("users")).SelectList(StructColumns(testUserPost{})),
+		},
+		{
+			name:    "insert select",
+			dialect: DefaultDialect{},
+			sqb:     Insert(users, nil, From(TableName("old_users")).Select(Column("id"), Cast(Column("name"), "text"))),
+		},
+		{
+			name:    "default values",
+			dialect: PostgreSQLDialect{},
+			sqb:     Insert(users, nil, InsertValuesStmt{}).Returning(),
+		},
+		{
+			name:    "batch update",
+			dialect: PostgreSQLDialect{},
+			sqb: BatchUpdateStmt{
+				Table:   users,
+				Key:     Column("id"),
+				Columns: []Column{"name"},
+				Rows:    [][]interface{}{{1, "a"}, {2, "b"}},
+				Types:   []string{"int", "text"},
+			},
+		},
+		{
+			name:    "mysql update join",
+			dialect: MySQLDialect{},
+			sqb:     UpdateStmt{Table: users, Set: SetStmt{{Key: Column("name"), Value: Arg{V: "a"}}}, From: UpdateFromStmt{Table: TableName("cities")}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := tt.dialect.ToSQL(tt.sqb)
+			if assert.NoError(t, err) {
+				assertRoundTrip(t, tt.dialect, tt.sqb)
+			}
+		})
+	}
+}
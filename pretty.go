@@ -0,0 +1,246 @@
+package sqb
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Formatter is optional interface of SQLWriter laying out rendered SQL.
+// Statements call it through helpers, writers without it get single line.
+type Formatter interface {
+	// Clause separates clause like FROM or WHERE from previous one.
+	Clause() error
+	// Continue separates continuation of clause like AND of WHERE.
+	Continue() error
+	// BeginStmt and EndStmt surround every SELECT, nested ones are subqueries.
+	BeginStmt() error
+	EndStmt() error
+	// BeginList, ListSep and EndList surround list which may be aligned.
+	BeginList()
+	ListSep() error
+	EndList()
+}
+
+// PrettyWriter formats SQL of underlying SQLWriter clause per line
+// with indented subqueries and aligned select lists.
+// Placeholders and args are left to underlying writer, so they do not change.
+type PrettyWriter struct {
+	W SQLWriter
+	// Indent is indentation of one nesting level, two spaces by default.
+	Indent string
+
+	depth   int
+	col     int
+	lists   []int
+	pending bool
+}
+
+func Pretty(w SQLWriter) *PrettyWriter {
+	return &PrettyWriter{W: w}
+}
+
+// Format renders statement of dialect formatted by PrettyWriter.
+func Format(d Dialect, s SQB) (string, []interface{}, error) {
+	err := Validate(s)
+	if err != nil {
+		return "", nil, err
+	}
+	qw := newWriter(d)
+	err = s.WriteSQLTo(Pretty(qw))
+	if err != nil {
+		return "", nil, err
+	}
+	q := qw.query()
+	return q.SQL, q.Args, nil
+}
+
+func (pw *PrettyWriter) WriteString(s string) (int, error) {
+	err := pw.flush()
+	if err != nil {
+		return 0, err
+	}
+	return pw.write(s)
+}
+
+func (pw *PrettyWriter) write(s string) (int, error) {
+	if i := strings.LastIndexByte(s, '\n'); i >= 0 {
+		pw.col = utf8.RuneCountInString(s[i+1:])
+	} else {
+		pw.col += utf8.RuneCountInString(s)
+	}
+	return pw.W.WriteString(s)
+}
+
+func (pw *PrettyWriter) AddArgs(a interface{}) error {
+	err := pw.flush()
+	if err != nil {
+		return err
+	}
+	return pw.measure(func() error {
+		return pw.W.AddArgs(a)
+	})
+}
+
+// AddNamedArg forwards named argument to underlying writer, if it binds them by name.
+func (pw *PrettyWriter) AddNamedArg(name string, v interface{}) error {
+	nw, ok := pw.W.(NamedArgWriter)
+	if !ok {
+		return pw.AddArgs(v)
+	}
+	err := pw.flush()
+	if err != nil {
+		return err
+	}
+	return pw.measure(func() error {
+		return nw.AddNamedArg(name, v)
+	})
+}
+
+// measure moves column by placeholder which f writes to underlying writer.
+func (pw *PrettyWriter) measure(f func() error) error {
+	l, ok := pw.W.(interface{ Len() int })
+	if !ok {
+		return f()
+	}
+	before := l.Len()
+	err := f()
+	pw.col += l.Len() - before
+	return err
+}
+
+func (pw *PrettyWriter) AppendRawArgs(a ...interface{}) error {
+	return pw.W.AppendRawArgs(a...)
+}
+
+func (pw *PrettyWriter) Dialect() Dialect {
+	return dialectOf(pw.W)
+}
+
+func (pw *PrettyWriter) Clause() error {
+	pw.pending = false
+	return pw.newline(pw.depth - 1)
+}
+
+func (pw *PrettyWriter) Continue() error {
+	pw.pending = false
+	return pw.newline(pw.depth)
+}
+
+func (pw *PrettyWriter) BeginStmt() error {
+	err := pw.flush()
+	if err != nil {
+		return err
+	}
+	pw.depth++
+	pw.lists = append(pw.lists, -1)
+	if pw.depth == 1 {
+		return nil
+	}
+	if pw.col == 0 {
+		_, err = pw.write(strings.Repeat(pw.indent(), pw.depth-1))
+		return err
+	}
+	return pw.newline(pw.depth - 1)
+}
+
+// EndStmt defers line break to next write, so it is not doubled by following clause.
+func (pw *PrettyWriter) EndStmt() error {
+	pw.depth--
+	pw.lists = pw.lists[:len(pw.lists)-1]
+	pw.pending = pw.depth > 0
+	return nil
+}
+
+func (pw *PrettyWriter) BeginList() {
+	pw.lists = append(pw.lists, pw.col)
+}
+
+func (pw *PrettyWriter) ListSep() error {
+	err := pw.flush()
+	if err != nil {
+		return err
+	}
+	if len(pw.lists) == 0 || pw.lists[len(pw.lists)-1] < 0 {
+		_, err = pw.write(", ")
+		return err
+	}
+	_, err = pw.write(",\n" + strings.Repeat(" ", pw.lists[len(pw.lists)-1]))
+	return err
+}
+
+func (pw *PrettyWriter) EndList() {
+	pw.lists = pw.lists[:len(pw.lists)-1]
+}
+
+func (pw *PrettyWriter) flush() error {
+	if !pw.pending {
+		return nil
+	}
+	pw.pending = false
+	return pw.newline(pw.depth - 1)
+}
+
+func (pw *PrettyWriter) newline(depth int) error {
+	if depth < 0 {
+		depth = 0
+	}
+	_, err := pw.write("\n" + strings.Repeat(pw.indent(), depth))
+	return err
+}
+
+func (pw *PrettyWriter) indent() string {
+	if pw.Indent == "" {
+		return "  "
+	}
+	return pw.Indent
+}
+
+func writeClause(w SQLWriter) error {
+	if f, ok := w.(Formatter); ok {
+		return f.Clause()
+	}
+	_, err := w.WriteString(" ")
+	return err
+}
+
+func writeContinue(w SQLWriter) error {
+	if f, ok := w.(Formatter); ok {
+		return f.Continue()
+	}
+	_, err := w.WriteString(" ")
+	return err
+}
+
+func beginStmt(w SQLWriter) error {
+	if f, ok := w.(Formatter); ok {
+		return f.BeginStmt()
+	}
+	return nil
+}
+
+func endStmt(w SQLWriter) error {
+	if f, ok := w.(Formatter); ok {
+		return f.EndStmt()
+	}
+	return nil
+}
+
+func beginList(w SQLWriter) {
+	if f, ok := w.(Formatter); ok {
+		f.BeginList()
+	}
+}
+
+func writeListSep(w SQLWriter) error {
+	if f, ok := w.(Formatter); ok {
+		return f.ListSep()
+	}
+	_, err := w.WriteString(", ")
+	return err
+}
+
+func endList(w SQLWriter) {
+	if f, ok := w.(Formatter); ok {
+		f.EndList()
+	}
+}
@@ -0,0 +1,110 @@
+package sqb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormat(t *testing.T) {
+	sub := From(TableName("orders")).Select(Column("user_id"), Column("total")).Where(Eq(Column("status"), Arg{V: "paid"})).As("o")
+
+	tests := []struct {
+		name     string
+		dialect  Dialect
+		sqb      SQB
+		expected string
+	}{
+		{
+			name:    "select",
+			dialect: PostgreSQLDialect{},
+			sqb: From(JB(TableName("users").As("u")).LeftJoin(sub, Eq(Column("o.user_id"), Column("u.id")))).
+				Select(Column("u.id"), Column("u.name"), Column("o.total")).
+				Where(Eq(Column("u.active"), Arg{V: true}), In(Column("u.id"), Arg{V: 1}, Arg{V: 2})).
+				OrderBy(Asc(Column("u.id"))).Limit(10),
+			expected: `SELECT u.id,
+       u.name,
+       o.total
+FROM users AS u
+LEFT JOIN (
+  SELECT user_id,
+         total
+  FROM orders
+  WHERE (status=$1)
+) AS o ON o.user_id=u.id
+WHERE (u.active=$2)
+  AND (u.id IN ($3, $4))
+ORDER BY u.id ASC
+LIMIT 10`,
+		},
+		{
+			name:    "insert select",
+			dialect: DefaultDialect{},
+			sqb:     Insert(TableName("archive"), []Column{"id"}, From(TableName("posts")).Select(Column("id"))).Returning(Column("id")),
+			expected: `INSERT INTO archive(id)
+  SELECT id
+  FROM posts
+RETURNING id`,
+		},
+		{
+			name:    "insert values",
+			dialect: DefaultDialect{},
+			sqb:     Insert(TableName("users"), []Column{"id", "name"}, InsertValuesStmt{{Arg{V: 1}, Arg{V: "a"}}, {Arg{V: 2}, Arg{V: "b"}}}),
+			expected: `INSERT INTO users(id, name)
+VALUES (?, ?),
+       (?, ?)`,
+		},
+		{
+			name:    "update",
+			dialect: PostgreSQLDialect{},
+			sqb: UpdateStmt{
+				Table: TableName("users"),
+				Set:   SetStmt{{Key: Column("name"), Value: Arg{V: "a"}}, {Key: Column("total"), Value: Column("o.total")}},
+				From:  UpdateFromStmt{Table: sub, On: Eq(Column("o.user_id"), Column("users.id"))},
+			},
+			expected: `UPDATE users
+SET name = $1,
+    total = o.total
+FROM (
+  SELECT user_id,
+         total
+  FROM orders
+  WHERE (status=$2)
+) AS o
+WHERE (o.user_id=users.id)`,
+		},
+		{
+			name:    "named args",
+			dialect: OracleDialect{},
+			sqb: From(TableName("posts")).
+				Select(Column("id"), Column("title")).
+				Where(Or(Eq(Column("author_id"), Named("user", 7)), Eq(Column("editor_id"), Named("user", 7))), Eq(Column("status"), Arg{V: "draft"})),
+			expected: `SELECT id,
+       title
+FROM posts
+WHERE ((author_id=:user) OR (editor_id=:user))
+  AND (status=:2)`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args, err := Format(tt.dialect, tt.sqb)
+			if !assert.NoError(t, err) {
+				return
+			}
+			assert.Equal(t, tt.expected, sql)
+
+			_, expectedArgs, err := tt.dialect.ToSQL(tt.sqb)
+			assert.NoError(t, err)
+			assert.Equal(t, expectedArgs, args)
+		})
+	}
+}
+
+func TestPrettyWriter_Indent(t *testing.T) {
+	w := &DefaultSQLWriter{}
+	pw := &PrettyWriter{W: w, Indent: "\t"}
+	err := From(From(TableName("users")).As("u")).WriteSQLTo(pw)
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT *\nFROM (\n\tSELECT *\n\tFROM users\n) AS u", w.String())
+}
@@ -13,11 +13,12 @@ func (rsql RawSQL) WriteSQLTo(st SQLWriter) error {
 	return st.AppendRawArgs(rsql.Args...)
 }
 
-func (RawSQL) IsJoinable()   {}
-func (RawSQL) IsComparable() {}
-func (RawSQL) IsTable()      {}
-func (RawSQL) IsCol()        {}
-func (RawSQL) IsOnExpr()     {}
+func (RawSQL) IsJoinable()    {}
+func (RawSQL) IsComparable()  {}
+func (RawSQL) IsTable()       {}
+func (RawSQL) IsCol()         {}
+func (RawSQL) IsOnExpr()      {}
+func (RawSQL) IsInsertValue() {}
 
 func Raw(query string, args ...interface{}) RawSQL {
 	return RawSQL{
@@ -0,0 +1,434 @@
+package sqb
+
+// Rewrite rebuilds statement tree bottom-up: children of node are rewritten first,
+// then f is called for node with rewritten children and its result replaces node.
+// f must return node assignable to field holding it, usually of the same type.
+// Children of nodes implementing Node are not rewritten.
+// Original tree is never modified.
+func Rewrite(node SQB, f func(SQB) SQB) SQB {
+	if node == nil {
+		return nil
+	}
+
+	switch n := node.(type) {
+	case Node:
+	case ColumnList:
+		n.Cols = rewriteList(n.Cols, f)
+		node = n
+	case ReturningStmt:
+		n.Cols = rewriteAs(n.Cols, f)
+		node = n
+	case SelectStmt:
+		node = rewriteSelect(n, f)
+	case SubqueryAlias:
+		n.SelectStmt = rewriteAs(n.SelectStmt, f)
+		node = n
+	case HintedTable:
+		n.Table = rewriteAs(n.Table, f)
+		node = n
+	case TableIdentifierAlias:
+		n.TableIdentifier = rewriteAs(n.TableIdentifier, f)
+		node = n
+	case JoinBuilder:
+		n.Joinable = rewriteAs(n.Joinable, f)
+		node = n
+	case InnerJoinStmt:
+		n.joinStmtWithOn = rewriteJoin(n.joinStmtWithOn, f)
+		node = n
+	case LeftJoinStmt:
+		n.joinStmtWithOn = rewriteJoin(n.joinStmtWithOn, f)
+		node = n
+	case RightJoinStmt:
+		n.joinStmtWithOn = rewriteJoin(n.joinStmtWithOn, f)
+		node = n
+	case FullOuterJoinStmt:
+		n.joinStmtWithOn = rewriteJoin(n.joinStmtWithOn, f)
+		node = n
+	case CrossJoinStmt:
+		n.LeftTable = rewriteAs(n.LeftTable, f)
+		n.RightTable = rewriteAs(n.RightTable, f)
+		node = n
+	case ValuesTable:
+		n.Values = rewriteAs(n.Values, f)
+		node = n
+	case WhereStmt:
+		n.Exprs = rewriteList(n.Exprs, f)
+		node = n
+	case EqExpr:
+		n.A = rewriteAs(n.A, f)
+		n.B = rewriteAs(n.B, f)
+		node = n
+	case OrExpr:
+		n.Exprs = rewriteList(n.Exprs, f)
+		node = n
+	case AndExpr:
+		n.Exprs = rewriteList(n.Exprs, f)
+		node = n
+	case OnOrExpr:
+		n.Exprs = rewriteList(n.Exprs, f)
+		node = n
+	case OnAndExpr:
+		n.Exprs = rewriteList(n.Exprs, f)
+		node = n
+	case OnBoolExpr:
+		n.Expr = rewriteAs(n.Expr, f)
+		node = n
+	case OnInExpr:
+		n.Some = rewriteAs(n.Some, f)
+		n.In = rewriteList(n.In, f)
+		node = n
+	case InExpr:
+		n.A = rewriteAs(n.A, f)
+		n.List = rewriteList(n.List, f)
+		node = n
+	case NullCheck:
+		n.A = rewriteAs(n.A, f)
+		node = n
+	case BinaryOperator:
+		n.Left = rewriteAs(n.Left, f)
+		n.Right = rewriteAs(n.Right, f)
+		node = n
+	case ExistsStmt:
+		n.Select = rewriteAs(n.Select, f)
+		node = n
+	case NotExpr:
+		n.expr = rewriteAs(n.expr, f)
+		node = n
+	case OrderByStmt:
+		n.Elems = rewriteList(n.Elems, f)
+		node = n
+	case OrderByElem:
+		n.C = rewriteAs(n.C, f)
+		node = n
+	case GroupByStmt:
+		n.Cols = rewriteList(n.Cols, f)
+		node = n
+	case AggrFuncCall:
+		n.Args = rewriteList(n.Args, f)
+		node = n
+	case CaseExpr:
+		n.Operand = rewriteAs(n.Operand, f)
+		whens := make([]WhenClause, 0, len(n.Whens))
+		for _, wc := range n.Whens {
+			whens = append(whens, WhenClause{When: rewriteAs(wc.When, f), Then: rewriteAs(wc.Then, f)})
+		}
+		n.Whens = whens
+		n.Else = rewriteAs(n.Else, f)
+		node = n
+	case CastExpr:
+		n.A = rewriteAs(n.A, f)
+		node = n
+	case ColumnAlias:
+		n.C = rewriteAs(n.C, f)
+		node = n
+	case QualifiedCol:
+		n.C = rewriteAs(n.C, f)
+		node = n
+	case InsertStmt:
+		n.Table = rewriteAs(n.Table, f)
+		n.Columns = rewriteList(n.Columns, f)
+		n.Source = rewriteAs(n.Source, f)
+		n.OnConflictStmt = rewriteAs(n.OnConflictStmt, f)
+		n.ReturningStmt = rewriteAs(n.ReturningStmt, f)
+		node = n
+	case OnConflictStmt:
+		n.Target = rewriteList(n.Target, f)
+		n.Set = rewriteAs(n.Set, f)
+		n.WhereStmt = rewriteAs(n.WhereStmt, f)
+		node = n
+	case InsertValuesStmt:
+		values := make(InsertValuesStmt, 0, len(n))
+		for _, line := range n {
+			values = append(values, rewriteList(line, f))
+		}
+		node = values
+	case UpdateStmt:
+		n.Table = rewriteAs(n.Table, f)
+		n.Set = rewriteAs(n.Set, f)
+		n.From.Table = rewriteAs(n.From.Table, f)
+		n.From.On = rewriteAs(n.From.On, f)
+		n.WhereStmt = rewriteAs(n.WhereStmt, f)
+		n.ReturningStmt = rewriteAs(n.ReturningStmt, f)
+		node = n
+	case DeleteStmt:
+		n.Table = rewriteAs(n.Table, f)
+		n.WhereStmt = rewriteAs(n.WhereStmt, f)
+		n.ReturningStmt = rewriteAs(n.ReturningStmt, f)
+		node = n
+	case SetStmt:
+		node = SetStmt(rewriteList(n, f))
+	case SetArg:
+		n.Key = rewriteAs(n.Key, f)
+		n.Value = rewriteAs(n.Value, f)
+		node = n
+	case BatchUpdateStmt:
+		n.Table = rewriteAs(n.Table, f)
+		n.Key = rewriteAs(n.Key, f)
+		n.Columns = rewriteList(n.Columns, f)
+		n.WhereStmt = rewriteAs(n.WhereStmt, f)
+		node = n
+	}
+	return f(node)
+}
+
+func rewriteAs[T SQB](node T, f func(SQB) SQB) T {
+	var zero T
+	if SQB(node) == nil {
+		return zero
+	}
+	r := Rewrite(node, f)
+	if r == nil {
+		return zero
+	}
+	return r.(T)
+}
+
+func rewriteList[T SQB](nodes []T, f func(SQB) SQB) []T {
+	if nodes == nil {
+		return nil
+	}
+	r := make([]T, 0, len(nodes))
+	for _, n := range nodes {
+		r = append(r, rewriteAs(n, f))
+	}
+	return r
+}
+
+func rewriteSelect(s SelectStmt, f func(SQB) SQB) SelectStmt {
+	s.Cols = rewriteAs(s.Cols, f)
+	s.From = rewriteAs(s.From, f)
+	if !s.WhereStmt.Empty() {
+		s.WhereStmt = rewriteAs(s.WhereStmt, f)
+	}
+	if !s.GroupByStmt.Empty() {
+		s.GroupByStmt = rewriteAs(s.GroupByStmt, f)
+	}
+	if !s.OrderByStmt.Empty() {
+		s.OrderByStmt = rewriteAs(s.OrderByStmt, f)
+	}
+	return s
+}
+
+func rewriteJoin(jso joinStmtWithOn, f func(SQB) SQB) joinStmtWithOn {
+	jso.LeftTable = rewriteAs(jso.LeftTable, f)
+	jso.RightTable = rewriteAs(jso.RightTable, f)
+	jso.on = rewriteAs(jso.on, f)
+	return jso
+}
+
+// OnBoolExpr makes any BoolExpr usable as join condition.
+type OnBoolExpr struct {
+	Expr BoolExpr
+}
+
+func OnBool(expr BoolExpr) OnBoolExpr {
+	return OnBoolExpr{Expr: expr}
+}
+
+func (OnBoolExpr) IsOnExpr() {}
+
+func (ob OnBoolExpr) WriteSQLTo(w SQLWriter) error {
+	return ob.Expr.WriteSQLTo(w)
+}
+
+// TableRule returns predicate for table referenced by qualifier,
+// which is its alias or name, or nil if table must not be filtered.
+// For raw SQL and VALUES list sources table is empty, and qualifier is alias of VALUES list.
+type TableRule func(table TableIdentifier, qualifier string) BoolExpr
+
+// Rewriter injects predicates of registered rules for every table referenced
+// by SELECT, UPDATE, batch UPDATE and DELETE statements, including subqueries.
+// Predicates go to WHERE, except tables on optional side of outer join,
+// which get them in ON, so join does not turn into inner one.
+// Neither filters sides of FULL OUTER JOIN, so their tables are replaced by
+// subqueries with predicates; such side which is join itself can not be filtered
+// and the statement fails validation.
+// Raw SQL and VALUES list sources can not be filtered either, so statement
+// fails validation when any rule returns predicate for them.
+type Rewriter struct {
+	rules []TableRule
+}
+
+func (r *Rewriter) Register(rule TableRule) {
+	r.rules = append(r.rules, rule)
+}
+
+func (r *Rewriter) Rewrite(node SQB) SQB {
+	return Rewrite(node, func(n SQB) SQB {
+		switch s := n.(type) {
+		case SelectStmt:
+			from, preds := r.filterFrom(s.From)
+			s.From = from
+			s.WhereStmt.Exprs = appendExprs(s.WhereStmt.Exprs, preds)
+			return s
+		case UpdateStmt:
+			preds := r.predicates(s.Table, string(s.Table))
+			if s.From.Table != nil {
+				from, fromPreds := r.filterFrom(s.From.Table)
+				s.From.Table = from.(Joinable)
+				preds = append(preds, fromPreds...)
+			}
+			s.WhereStmt.Exprs = appendExprs(s.WhereStmt.Exprs, preds)
+			return s
+		case BatchUpdateStmt:
+			preds := r.predicates(s.Table, string(s.Table))
+			s.WhereStmt.Exprs = appendExprs(s.WhereStmt.Exprs, preds)
+			return s
+		case DeleteStmt:
+			preds := r.predicates(s.Table, string(s.Table))
+			s.WhereStmt.Exprs = appendExprs(s.WhereStmt.Exprs, preds)
+			return s
+		}
+		return n
+	})
+}
+
+func (r *Rewriter) predicates(table TableIdentifier, qualifier string) []BoolExpr {
+	var preds []BoolExpr
+	for _, rule := range r.rules {
+		if p := rule(table, qualifier); p != nil {
+			preds = append(preds, p)
+		}
+	}
+	return preds
+}
+
+// filterFrom returns table with predicates of optional sides of outer joins in ON,
+// and predicates for WHERE.
+func (r *Rewriter) filterFrom(t Table) (Table, []BoolExpr) {
+	switch n := t.(type) {
+	case TableIdentifier:
+		return n, r.predicates(n, string(n))
+	case TableIdentifierAlias:
+		return n, r.predicates(n.TableIdentifier, n.AS)
+	case HintedTable:
+		t, preds := r.filterFrom(n.Table)
+		n.Table = t.(Joinable)
+		return n, preds
+	case JoinBuilder:
+		j, preds := r.filterFrom(n.Joinable)
+		return JoinBuilder{Joinable: j.(Joinable)}, preds
+	case InnerJoinStmt:
+		l, lp, rt, rp := r.filterSides(n.joinStmt)
+		n.LeftTable, n.RightTable = l, rt
+		return n, append(lp, rp...)
+	case LeftJoinStmt:
+		l, lp, rt, rp := r.filterSides(n.joinStmt)
+		n.LeftTable, n.RightTable = l, rt
+		n.on = onWith(n.on, rp)
+		return n, lp
+	case RightJoinStmt:
+		l, lp, rt, rp := r.filterSides(n.joinStmt)
+		n.LeftTable, n.RightTable = l, rt
+		n.on = onWith(n.on, lp)
+		return n, rp
+	case FullOuterJoinStmt:
+		l, lok := r.filterFullSide(n.LeftTable)
+		rt, rok := r.filterFullSide(n.RightTable)
+		n.LeftTable, n.RightTable = l, rt
+		if !lok || !rok {
+			return unfilteredJoin{n}, nil
+		}
+		return n, nil
+	case CrossJoinStmt:
+		l, lp, rt, rp := r.filterSides(n.joinStmt)
+		n.LeftTable, n.RightTable = l, rt
+		return n, append(lp, rp...)
+	case RawSQL:
+		return r.refuse(n, "")
+	case ValuesTable:
+		return r.refuse(n, n.AS)
+	}
+	return t, nil
+}
+
+// refuse returns source which is not filtered when rules have no predicates for it.
+func (r *Rewriter) refuse(t Joinable, qualifier string) (Table, []BoolExpr) {
+	if len(r.predicates("", qualifier)) != 0 {
+		return unfilteredTable{t}, nil
+	}
+	return t, nil
+}
+
+func (r *Rewriter) filterSides(js joinStmt) (Joinable, []BoolExpr, Joinable, []BoolExpr) {
+	l, lp := r.filterFrom(js.LeftTable)
+	rt, rp := r.filterFrom(js.RightTable)
+	return l.(Joinable), lp, rt.(Joinable), rp
+}
+
+// filterFullSide filters side of FULL OUTER JOIN, which rows neither ON nor WHERE drop:
+// table is replaced by subquery with its predicates. False is returned for join with predicates.
+func (r *Rewriter) filterFullSide(t Joinable) (Joinable, bool) {
+	f, preds := r.filterFrom(t)
+	if len(preds) == 0 {
+		return f.(Joinable), true
+	}
+	var qualifier string
+	switch n := f.(type) {
+	case TableIdentifier:
+		qualifier = string(n)
+	case TableIdentifierAlias:
+		qualifier = n.AS
+	case HintedTable:
+		switch t := n.Table.(type) {
+		case TableIdentifier:
+			qualifier = string(t)
+		case TableIdentifierAlias:
+			qualifier = t.AS
+		default:
+			return n, false
+		}
+	default:
+		return f.(Joinable), false
+	}
+	return From(f.(Table)).Where(preds...).As(qualifier), true
+}
+
+// unfilteredJoin is FULL OUTER JOIN which side is join with predicates Rewriter can not apply,
+// it is refused by Validate and rendering.
+type unfilteredJoin struct {
+	FullOuterJoinStmt
+}
+
+func (uj unfilteredJoin) err() error {
+	return &InvalidStatementError{Node: "FullOuterJoinStmt", Msg: "predicates of tables in joined side of FULL OUTER JOIN can not be applied"}
+}
+
+func (uj unfilteredJoin) WriteSQLTo(w SQLWriter) error {
+	return uj.err()
+}
+
+// unfilteredTable is raw SQL or VALUES list source rules have predicates for,
+// it is refused by Validate and rendering.
+type unfilteredTable struct {
+	Joinable
+}
+
+func (ut unfilteredTable) err() error {
+	return invalid(ut.Joinable, "predicates of rules can not be applied to "+nodeName(ut.Joinable)+" source")
+}
+
+func (ut unfilteredTable) WriteSQLTo(w SQLWriter) error {
+	return ut.err()
+}
+
+func onWith(on OnExpr, preds []BoolExpr) OnExpr {
+	if len(preds) == 0 {
+		return on
+	}
+	exprs := make([]OnExpr, 0, len(preds)+1)
+	exprs = append(exprs, on)
+	for _, p := range preds {
+		exprs = append(exprs, OnBool(p))
+	}
+	return OnAndExpr{Exprs: exprs}
+}
+
+func appendExprs(exprs []BoolExpr, preds []BoolExpr) []BoolExpr {
+	if len(preds) == 0 {
+		return exprs
+	}
+	r := make([]BoolExpr, 0, len(exprs)+len(preds))
+	r = append(r, exprs...)
+	return append(r, preds...)
+}
@@ -0,0 +1,189 @@
+package sqb
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testRewriter() *Rewriter {
+	r := &Rewriter{}
+	r.Register(func(table TableIdentifier, qualifier string) BoolExpr {
+		return Eq(Column(qualifier+".tenant_id"), Arg{V: 7})
+	})
+	r.Register(func(table TableIdentifier, qualifier string) BoolExpr {
+		if table != "posts" {
+			return nil
+		}
+		return NullCheck{A: Column(qualifier + ".deleted_at"), IsNull: true}
+	})
+	return r
+}
+
+func TestRewriter_Rewrite(t *testing.T) {
+	tests := []struct {
+		name           string
+		sqb            SQB
+		expectedRawSQL string
+		expectedArgs   []interface{}
+	}{
+		{
+			name:           "select",
+			sqb:            From(TableName("posts")).Where(Eq(Column("id"), Arg{V: 1})),
+			expectedRawSQL: "SELECT * FROM posts WHERE (id=?) AND (posts.tenant_id=?) AND (posts.deleted_at IS NULL)",
+			expectedArgs:   []interface{}{1, 7},
+		},
+		{
+			name: "left join",
+			sqb: From(
+				LeftJoin(TableName("users").As("u"), TableName("posts").As("p"), Eq(Column("u.id"), Column("p.user_id"))),
+			),
+			expectedRawSQL: "SELECT * FROM users AS u LEFT JOIN posts AS p ON (u.id=p.user_id) AND (p.tenant_id=?) AND (p.deleted_at IS NULL) WHERE (u.tenant_id=?)",
+			expectedArgs:   []interface{}{7, 7},
+		},
+		{
+			name: "inner join",
+			sqb: From(
+				JB(TableName("users")).InnerJoin(TableName("cities"), Eq(Column("users.city_id"), Column("cities.id"))),
+			),
+			expectedRawSQL: "SELECT * FROM users INNER JOIN cities ON users.city_id=cities.id WHERE (users.tenant_id=?) AND (cities.tenant_id=?)",
+			expectedArgs:   []interface{}{7, 7},
+		},
+		{
+			name: "subqueries",
+			sqb: From(From(TableName("users")).As("u")).
+				Where(ExistsStmt{Select: From(TableName("posts")).Where(Eq(Column("posts.user_id"), Column("u.id")))}),
+			expectedRawSQL: "SELECT * FROM (SELECT * FROM users WHERE (users.tenant_id=?)) AS u WHERE (exists(SELECT * FROM posts WHERE (posts.user_id=u.id) AND (posts.tenant_id=?) AND (posts.deleted_at IS NULL)))",
+			expectedArgs:   []interface{}{7, 7},
+		},
+		{
+			name: "update from",
+			sqb: UpdateStmt{
+				Table: TableName("posts"),
+				Set:   SetStmt{{Key: Column("title"), Value: Column("drafts.title")}},
+				From: UpdateFromStmt{
+					Table: TableName("drafts"),
+					On:    Eq(Column("posts.id"), Column("drafts.post_id")),
+				},
+			},
+			expectedRawSQL: "UPDATE posts SET title = drafts.title FROM drafts WHERE (posts.id=drafts.post_id) AND (posts.tenant_id=?) AND (posts.deleted_at IS NULL) AND (drafts.tenant_id=?)",
+			expectedArgs:   []interface{}{7, 7},
+		},
+		{
+			name: "full outer join",
+			sqb: From(
+				FullOuterJoin(TableName("users").As("u"), TableName("posts"), Eq(Column("u.id"), Column("posts.user_id"))),
+			),
+			expectedRawSQL: "SELECT * FROM (SELECT * FROM users AS u WHERE (u.tenant_id=?)) AS u FULL OUTER JOIN (SELECT * FROM posts WHERE (posts.tenant_id=?) AND (posts.deleted_at IS NULL)) AS posts ON u.id=posts.user_id",
+			expectedArgs:   []interface{}{7, 7},
+		},
+		{
+			name:           "batch update",
+			sqb:            BatchUpdate(TableName("posts"), Column("id"), []Column{"title"}, [][]interface{}{{1, "a"}}),
+			expectedRawSQL: "UPDATE posts SET title = CASE id WHEN ? THEN ? ELSE title END WHERE (id IN (?)) AND (posts.tenant_id=?) AND (posts.deleted_at IS NULL)",
+			expectedArgs:   []interface{}{1, "a", 1, 7},
+		},
+		{
+			name:           "delete",
+			sqb:            Delete(TableName("posts")).Where(Eq(Column("id"), Arg{V: 1})),
+			expectedRawSQL: "DELETE FROM posts WHERE (id=?) AND (posts.tenant_id=?) AND (posts.deleted_at IS NULL)",
+			expectedArgs:   []interface{}{1, 7},
+		},
+		{
+			name:           "insert from select",
+			sqb:            Insert(TableName("archive"), []Column{"id"}, From(TableName("posts")).Select(Column("id"))),
+			expectedRawSQL: "INSERT INTO archive(id) SELECT id FROM posts WHERE (posts.tenant_id=?) AND (posts.deleted_at IS NULL)",
+			expectedArgs:   []interface{}{7},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before, _, err := ToSQL(tt.sqb)
+			assert.NoError(t, err)
+
+			builded, args, err := ToSQL(testRewriter().Rewrite(tt.sqb))
+			assert.NoError(t, err)
+			if builded != tt.expectedRawSQL {
+				t.Errorf("WriteSQLTo() raw SQL expected = %v, actual = %v", tt.expectedRawSQL, builded)
+			}
+			assert.Equal(t, tt.expectedArgs, args)
+			assertRoundTrip(t, DefaultDialect{}, testRewriter().Rewrite(tt.sqb))
+
+			after, _, err := ToSQL(tt.sqb)
+			assert.NoError(t, err)
+			assert.Equal(t, before, after, "original tree must not change")
+		})
+	}
+}
+
+func TestRewriter_FullOuterJoinOfJoin(t *testing.T) {
+	s := From(FullOuterJoin(
+		InnerJoin(TableName("users"), TableName("cities"), Eq(Column("users.city_id"), Column("cities.id"))),
+		TableName("posts"), Eq(Column("users.id"), Column("posts.user_id")),
+	))
+	r := testRewriter().Rewrite(s)
+
+	err := Validate(r)
+	assert.True(t, errors.Is(err, ErrInvalidStatement))
+	assert.EqualError(t, err, "sqb: invalid statement: SelectStmt.From: predicates of tables in joined side of FULL OUTER JOIN can not be applied")
+
+	_, _, err = ToSQLInto(DefaultDialect{}, r, nil, nil)
+	assert.True(t, errors.Is(err, ErrInvalidStatement))
+}
+
+func TestRewriter_UnfilteredSource(t *testing.T) {
+	values := InsertValuesStmt{{Arg{V: 1}}}.As("v", "id")
+	tests := []struct {
+		name     string
+		sqb      SQB
+		expected string
+	}{
+		{
+			name:     "raw",
+			sqb:      From(Raw("users")),
+			expected: "sqb: invalid statement: SelectStmt.From: predicates of rules can not be applied to RawSQL source",
+		},
+		{
+			name:     "values",
+			sqb:      From(InnerJoin(TableName("users"), values, Eq(Column("users.id"), Column("v.id")))),
+			expected: "sqb: invalid statement: SelectStmt.From.RightTable: predicates of rules can not be applied to ValuesTable source",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := testRewriter().Rewrite(tt.sqb)
+			err := Validate(r)
+			assert.True(t, errors.Is(err, ErrInvalidStatement))
+			assert.EqualError(t, err, tt.expected)
+
+			_, _, err = ToSQLInto(DefaultDialect{}, r, nil, nil)
+			assert.True(t, errors.Is(err, ErrInvalidStatement))
+		})
+	}
+
+	posts := &Rewriter{}
+	posts.Register(func(table TableIdentifier, qualifier string) BoolExpr {
+		if table != "posts" {
+			return nil
+		}
+		return NullCheck{A: Column(qualifier + ".deleted_at"), IsNull: true}
+	})
+	query, _, err := ToSQL(posts.Rewrite(From(values)))
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM (VALUES (?)) AS v(id)", query)
+}
+
+func TestRewrite(t *testing.T) {
+	s := From(TableName("users")).Where(Eq(Column("name"), Arg{V: "alice"}), In(Column("id"), Arg{V: 1}))
+	r := Rewrite(s, func(node SQB) SQB {
+		if _, ok := node.(Arg); ok {
+			return Arg{V: "x"}
+		}
+		return node
+	})
+	builded, args, err := ToSQL(r)
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM users WHERE (name=?) AND (id IN (?))", builded)
+	assert.Equal(t, []interface{}{"x", "x"}, args)
+}
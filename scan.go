@@ -0,0 +1,175 @@
+package sqb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+var (
+	scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+	timeType    = reflect.TypeOf(time.Time{})
+)
+
+// ScanRows scans rows into dest and closes rows.
+// dest is pointer to struct, map[string]interface{} or scalar, which gets first row
+// or sql.ErrNoRows, or pointer to slice of them or of pointers to them, which gets all rows.
+// Columns are matched against field tags the same way StructColumns names them,
+// every column must have destination field.
+func ScanRows(rows *sql.Rows, dest interface{}) error {
+	defer rows.Close()
+
+	dv := reflect.ValueOf(dest)
+	if dv.Kind() != reflect.Ptr || dv.IsNil() {
+		return fmt.Errorf("sqb: scan destination must be non-nil pointer, got %T", dest)
+	}
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+
+	target := dv.Elem()
+	if target.Kind() != reflect.Slice || target.Type().Elem().Kind() == reflect.Uint8 {
+		if !rows.Next() {
+			if err := rows.Err(); err != nil {
+				return err
+			}
+			return sql.ErrNoRows
+		}
+
+		err = newRowScanner(target.Type(), columns).scan(rows, target)
+		if err != nil {
+			return err
+		}
+		return rows.Close()
+	}
+
+	elemType := target.Type().Elem()
+	isPtr := elemType.Kind() == reflect.Ptr
+	if isPtr {
+		elemType = elemType.Elem()
+	}
+
+	rs := newRowScanner(elemType, columns)
+	result := reflect.MakeSlice(target.Type(), 0, 0)
+	for rows.Next() {
+		ev := reflect.New(elemType)
+		err = rs.scan(rows, ev.Elem())
+		if err != nil {
+			return err
+		}
+
+		if isPtr {
+			result = reflect.Append(result, ev)
+			continue
+		}
+		result = reflect.Append(result, ev.Elem())
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	target.Set(result)
+	return nil
+}
+
+// ScanContext renders s in dialect d, runs it with q and scans result into dest as ScanRows does.
+func ScanContext(ctx context.Context, q QueryerContext, d Dialect, s SQB, dest interface{}) error {
+	rows, err := QueryContext(ctx, q, d, s)
+	if err != nil {
+		return err
+	}
+	return ScanRows(rows, dest)
+}
+
+type rowScanner struct {
+	t       reflect.Type
+	columns []string
+	fields  [][]int
+	err     error
+}
+
+func newRowScanner(t reflect.Type, columns []string) rowScanner {
+	rs := rowScanner{t: t, columns: columns}
+	if !isStructRow(t) {
+		return rs
+	}
+
+	byName := make(map[string][]int)
+	for _, f := range structFields(t) {
+		byName[f.name] = f.index
+	}
+
+	rs.fields = make([][]int, 0, len(columns))
+	for _, c := range columns {
+		index, ok := byName[c]
+		if !ok {
+			rs.err = fmt.Errorf("sqb: no field for column %q in %s", c, t)
+			return rs
+		}
+		rs.fields = append(rs.fields, index)
+	}
+	return rs
+}
+
+func isStructRow(t reflect.Type) bool {
+	return t.Kind() == reflect.Struct && t != timeType && !reflect.PtrTo(t).Implements(scannerType)
+}
+
+func (rs rowScanner) scan(rows *sql.Rows, v reflect.Value) error {
+	if rs.err != nil {
+		return rs.err
+	}
+
+	switch {
+	case isStructRow(rs.t):
+		dests := make([]interface{}, 0, len(rs.fields))
+		for _, index := range rs.fields {
+			dests = append(dests, allocFieldValue(v, index).Addr().Interface())
+		}
+		return rows.Scan(dests...)
+	case rs.t.Kind() == reflect.Map:
+		if rs.t.Key().Kind() != reflect.String || rs.t.Elem().Kind() != reflect.Interface {
+			return fmt.Errorf("sqb: map scan destination must be map[string]interface{}, got %s", rs.t)
+		}
+
+		values := make([]interface{}, len(rs.columns))
+		dests := make([]interface{}, len(rs.columns))
+		for i := range values {
+			dests[i] = &values[i]
+		}
+
+		err := rows.Scan(dests...)
+		if err != nil {
+			return err
+		}
+
+		if v.IsNil() {
+			v.Set(reflect.MakeMapWithSize(rs.t, len(rs.columns)))
+		}
+		for i, c := range rs.columns {
+			val := reflect.ValueOf(&values[i]).Elem()
+			v.SetMapIndex(reflect.ValueOf(c).Convert(rs.t.Key()), val)
+		}
+		return nil
+	default:
+		return rows.Scan(v.Addr().Interface())
+	}
+}
+
+// allocFieldValue returns field of struct value v, allocating nil embedded pointers.
+func allocFieldValue(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
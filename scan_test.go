@@ -0,0 +1,90 @@
+package sqb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScanRows(t *testing.T) {
+	created := time.Date(2020, 05, 14, 13, 32, 00, 00, time.UTC)
+	db, _ := openFakeDB(t,
+		[]string{"id", "name", "bio", "created_at", "updated_at"},
+		[]driver.Value{int64(1), "alice", "hi", created, created},
+		[]driver.Value{int64(2), "bob", "", created, created},
+	)
+	defer db.Close()
+	ctx := context.Background()
+	s := From(TableName("users")).SelectList(StructColumns(testUser{}))
+
+	var user testUser
+	assert.NoError(t, ScanContext(ctx, db, DefaultDialect{}, s, &user))
+	assert.Equal(t, testUser{ID: 1, Name: "alice", Bio: "hi", testTimestamps: testTimestamps{CreatedAt: created, UpdatedAt: created}}, user)
+
+	var users []testUser
+	assert.NoError(t, ScanContext(ctx, db, DefaultDialect{}, s, &users))
+	assert.Len(t, users, 2)
+	assert.Equal(t, "bob", users[1].Name)
+
+	var userPtrs []*testUser
+	assert.NoError(t, ScanContext(ctx, db, DefaultDialect{}, s, &userPtrs))
+	assert.Len(t, userPtrs, 2)
+	assert.Equal(t, int64(2), userPtrs[1].ID)
+
+	var m map[string]interface{}
+	assert.NoError(t, ScanContext(ctx, db, DefaultDialect{}, s, &m))
+	assert.Equal(t, map[string]interface{}{"id": int64(1), "name": "alice", "bio": "hi", "created_at": created, "updated_at": created}, m)
+
+	var ms []map[string]interface{}
+	assert.NoError(t, ScanContext(ctx, db, DefaultDialect{}, s, &ms))
+	assert.Len(t, ms, 2)
+
+	var posts []testPost
+	assert.Error(t, ScanContext(ctx, db, DefaultDialect{}, s, &posts))
+
+	assert.Error(t, ScanContext(ctx, db, DefaultDialect{}, s, user))
+}
+
+func TestScanRows_Joined(t *testing.T) {
+	db, _ := openFakeDB(t,
+		[]string{"u.id", "u.name", "u.bio", "u.created_at", "u.updated_at", "p.id", "p.title", "rank"},
+		[]driver.Value{int64(1), "alice", "", time.Time{}, time.Time{}, int64(7), "hello", int64(3)},
+	)
+	defer db.Close()
+
+	var rows []testUserPost
+	err := ScanContext(context.Background(), db, DefaultDialect{}, From(TableName("users")).SelectList(StructColumns(testUserPost{})), &rows)
+	assert.NoError(t, err)
+	assert.Equal(t, []testUserPost{{
+		testUser: testUser{ID: 1, Name: "alice"},
+		testPost: testPost{ID: 7, Title: "hello"},
+		Rank:     3,
+	}}, rows)
+}
+
+func TestScanRows_Scalars(t *testing.T) {
+	db, _ := openFakeDB(t, []string{"count"}, []driver.Value{int64(42)})
+	defer db.Close()
+	ctx := context.Background()
+
+	var cnt int64
+	assert.NoError(t, ScanContext(ctx, db, DefaultDialect{}, From(TableName("users")).Select(Count()), &cnt))
+	assert.Equal(t, int64(42), cnt)
+
+	var counts []sql.NullInt64
+	assert.NoError(t, ScanContext(ctx, db, DefaultDialect{}, From(TableName("users")).Select(Count()), &counts))
+	assert.Equal(t, []sql.NullInt64{{Int64: 42, Valid: true}}, counts)
+}
+
+func TestScanRows_NoRows(t *testing.T) {
+	db, _ := openFakeDB(t, []string{"id"})
+	defer db.Close()
+
+	var id int64
+	err := ScanContext(context.Background(), db, DefaultDialect{}, From(TableName("users")).Select(Column("id")), &id)
+	assert.Equal(t, sql.ErrNoRows, err)
+}
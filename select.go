@@ -1,8 +1,6 @@
 package sqb
 
-import (
-	"strconv"
-)
+import "strconv"
 
 type SQB interface {
 	WriteSQLTo(SQLWriter) error
@@ -26,6 +24,8 @@ func NewColumnList(cols ...Col) ColumnList {
 	}
 }
 
+// WithPrefix qualifies columns of list by table prefix,
+// except QualifiedCol, like nested fields of StructColumns.
 func (cl ColumnList) WithPrefix(prefix string) ColumnList {
 	cl.Prefix = prefix
 	return cl
@@ -36,7 +36,12 @@ type ReturningStmt struct {
 }
 
 func (cl ReturningStmt) WriteSQLTo(w SQLWriter) error {
-	_, err := w.WriteString(" RETURNING ")
+	err := writeClause(w)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.WriteString("RETURNING ")
 	if err != nil {
 		return err
 	}
@@ -51,8 +56,8 @@ func (cl ColumnList) WriteSQLTo(w SQLWriter) error {
 		return err
 	}
 
-	if cl.Prefix != "" {
-		_, err = w.WriteString(cl.Prefix + ".")
+	if _, ok := cl.Cols[0].(QualifiedCol); cl.Prefix != "" && !ok {
+		err = writeQualifier(w, cl.Prefix)
 		if err != nil {
 			return err
 		}
@@ -68,13 +73,13 @@ func (cl ColumnList) WriteSQLTo(w SQLWriter) error {
 	}
 
 	for _, c := range cl.Cols[1:] {
-		_, err = w.WriteString(", ")
+		err = writeListSep(w)
 		if err != nil {
 			return err
 		}
 
-		if cl.Prefix != "" {
-			_, err = w.WriteString(cl.Prefix + ".")
+		if _, ok := c.(QualifiedCol); cl.Prefix != "" && !ok {
+			err = writeQualifier(w, cl.Prefix)
 			if err != nil {
 				return err
 			}
@@ -164,7 +169,12 @@ func From(fs Table) SelectStmt {
 }
 
 func (s SelectStmt) WriteSQLTo(st SQLWriter) error {
-	_, err := st.WriteString(`SELECT `)
+	err := beginStmt(st)
+	if err != nil {
+		return err
+	}
+
+	_, err = st.WriteString(`SELECT `)
 	if err != nil {
 		return err
 	}
@@ -180,12 +190,23 @@ func (s SelectStmt) WriteSQLTo(st SQLWriter) error {
 		s.Cols = NewColumnList()
 	}
 
+	beginList(st)
 	err = s.Cols.WriteSQLTo(st)
 	if err != nil {
 		return err
 	}
+	endList(st)
 
-	_, err = st.WriteString(" FROM ")
+	if s.From == nil {
+		return nilNode(s, "From")
+	}
+
+	err = writeClause(st)
+	if err != nil {
+		return err
+	}
+
+	_, err = st.WriteString("FROM ")
 	if err != nil {
 		return err
 	}
@@ -196,7 +217,7 @@ func (s SelectStmt) WriteSQLTo(st SQLWriter) error {
 	}
 
 	if !s.WhereStmt.Empty() {
-		_, err = st.WriteString(` `)
+		err = writeClause(st)
 		if err != nil {
 			return err
 		}
@@ -208,7 +229,7 @@ func (s SelectStmt) WriteSQLTo(st SQLWriter) error {
 	}
 
 	if !s.GroupByStmt.Empty() {
-		_, err = st.WriteString(` `)
+		err = writeClause(st)
 		if err != nil {
 			return err
 		}
@@ -220,7 +241,7 @@ func (s SelectStmt) WriteSQLTo(st SQLWriter) error {
 	}
 
 	if !s.OrderByStmt.Empty() {
-		_, err = st.WriteString(` `)
+		err = writeClause(st)
 		if err != nil {
 			return err
 		}
@@ -231,20 +252,25 @@ func (s SelectStmt) WriteSQLTo(st SQLWriter) error {
 		}
 	}
 
+	offsetInLimit := !s.OffsetStmt.Empty() && limitComma(st)
 	if !s.LimitStmt.Empty() {
-		_, err = st.WriteString(` `)
+		err = writeClause(st)
 		if err != nil {
 			return err
 		}
 
-		err = s.LimitStmt.WriteSQLTo(st)
+		if offsetInLimit {
+			err = s.LimitStmt.writeWithOffset(st, s.OffsetStmt)
+		} else {
+			err = s.LimitStmt.WriteSQLTo(st)
+		}
 		if err != nil {
 			return err
 		}
 	}
 
-	if !s.OffsetStmt.Empty() {
-		_, err = st.WriteString(` `)
+	if !s.OffsetStmt.Empty() && !offsetInLimit {
+		err = writeClause(st)
 		if err != nil {
 			return err
 		}
@@ -256,12 +282,17 @@ func (s SelectStmt) WriteSQLTo(st SQLWriter) error {
 	}
 	// must be last statement in query
 	if s.IsForUpdate {
-		_, err := st.WriteString(` FOR UPDATE`)
+		err = writeClause(st)
+		if err != nil {
+			return err
+		}
+
+		_, err = st.WriteString(`FOR UPDATE`)
 		if err != nil {
 			return err
 		}
 	}
-	return nil
+	return endStmt(st)
 }
 
 func (cs SelectStmt) Where(exprs ...BoolExpr) SelectStmt {
@@ -280,7 +311,7 @@ func (cs SelectStmt) Limit(limit uint64) SelectStmt {
 	return cp
 }
 
-// TODO: allow it only for limited query
+// Offset requires Limit, Validate reports offset of unlimited query.
 func (cs SelectStmt) Offset(offset uint64) SelectStmt {
 	cp := cs
 	cp.OffsetStmt = OffsetStmt{
@@ -308,8 +339,11 @@ func (js SubqueryAlias) WriteSQLTo(st SQLWriter) error {
 	if err := js.SelectStmt.WriteSQLTo(st); err != nil {
 		return err
 	}
-	_, err := st.WriteString(`) AS ` + js.AS)
-	return err
+	_, err := st.WriteString(`) AS `)
+	if err != nil {
+		return err
+	}
+	return writeAlias(st, js.AS)
 }
 
 type Joinable interface {
@@ -317,13 +351,14 @@ type Joinable interface {
 	IsJoinable()
 }
 
-func (InnerJoinStmt) IsJoinable()     {}
-func (LeftJoinStmt) IsJoinable()      {}
-func (FullOuterJoinStmt) IsJoinable() {}
-func (RightJoinStmt) IsJoinable()     {}
-func (CrossJoinStmt) IsJoinable()     {}
-func (TableIdentifier) IsJoinable()   {}
-func (SubqueryAlias) IsJoinable()     {}
+func (InnerJoinStmt) IsJoinable()        {}
+func (LeftJoinStmt) IsJoinable()         {}
+func (FullOuterJoinStmt) IsJoinable()    {}
+func (RightJoinStmt) IsJoinable()        {}
+func (CrossJoinStmt) IsJoinable()        {}
+func (TableIdentifier) IsJoinable()      {}
+func (TableIdentifierAlias) IsJoinable() {}
+func (SubqueryAlias) IsJoinable()        {}
 
 type joinStmt struct {
 	kind                  string
@@ -343,7 +378,15 @@ func (js joinStmt) WriteSQLTo(st SQLWriter) error {
 	if err != nil {
 		return err
 	}
-	_, err = st.WriteString(" " + js.kind + " JOIN ")
+	err = writeClause(st)
+	if err != nil {
+		return err
+	}
+	_, err = st.WriteString(js.kind)
+	if err != nil {
+		return err
+	}
+	_, err = st.WriteString(" JOIN ")
 	if err != nil {
 		return err
 	}
@@ -542,6 +585,16 @@ func FullOuterJoin(left, right Joinable, on OnExpr) FullOuterJoinStmt {
 	}
 }
 
+func (fj FullOuterJoinStmt) WriteSQLTo(st SQLWriter) error {
+	if isMySQL(st) {
+		return unsupported(st, fj, "", "FULL OUTER JOIN")
+	}
+	if err := sqliteUnsupported(st, fj, "", "FULL OUTER JOIN", sqliteRightJoin); err != nil {
+		return err
+	}
+	return fj.joinStmtWithOn.WriteSQLTo(st)
+}
+
 type RightJoinStmt struct {
 	joinStmtWithOn
 }
@@ -552,8 +605,18 @@ func RightJoin(left, right Joinable, on OnExpr) RightJoinStmt {
 	}
 }
 
+func (rj RightJoinStmt) WriteSQLTo(st SQLWriter) error {
+	if err := sqliteUnsupported(st, rj, "", "RIGHT JOIN", sqliteRightJoin); err != nil {
+		return err
+	}
+	return rj.joinStmtWithOn.WriteSQLTo(st)
+}
+
 type CrossJoinStmt struct {
 	joinStmt
+	// discardedOn is ON condition given to JoinBuilder.CrossJoin,
+	// it is never rendered and only reported by Validate.
+	discardedOn OnExpr
 }
 
 func CrossJoin(l, r Joinable) CrossJoinStmt {
@@ -576,8 +639,7 @@ func (tns TableIdentifier) As(name string) TableIdentifierAlias {
 }
 
 func (tn TableIdentifier) WriteSQLTo(st SQLWriter) error {
-	_, err := st.WriteString(string(tn))
-	return err
+	return writeIdent(st, string(tn))
 }
 
 type TableIdentifierAlias struct {
@@ -590,8 +652,11 @@ func (tn TableIdentifierAlias) WriteSQLTo(st SQLWriter) error {
 	if err != nil {
 		return err
 	}
-	_, err = st.WriteString(` AS ` + tn.AS)
-	return err
+	_, err = st.WriteString(` AS `)
+	if err != nil {
+		return err
+	}
+	return writeAlias(st, tn.AS)
 }
 
 type WhereStmt struct {
@@ -624,7 +689,11 @@ func (ws WhereStmt) WriteSQLTo(st SQLWriter) error {
 	}
 
 	for _, ex := range ws.Exprs[1:] {
-		_, err := st.WriteString(` AND `)
+		err := writeContinue(st)
+		if err != nil {
+			return err
+		}
+		_, err = st.WriteString(`AND `)
 		if err != nil {
 			return err
 		}
@@ -651,10 +720,75 @@ type Column string
 func (Column) IsCol() {}
 
 func (c Column) WriteSQLTo(st SQLWriter) error {
-	_, err := st.WriteString(string(c))
+	return writeIdent(st, string(c))
+}
+
+// writeQualifier writes name of table qualifying column followed by dot.
+func writeQualifier(w SQLWriter, name string) error {
+	err := writeIdent(w, name)
+	if err != nil {
+		return err
+	}
+	_, err = w.WriteString(".")
 	return err
 }
 
+func (c Column) As(name string) ColumnAlias {
+	return ColumnAlias{
+		C:  c,
+		AS: name,
+	}
+}
+
+// ColumnAlias is select list element with alias: C AS name.
+// Alias which is not plain identifier is quoted, with backticks in MySQL.
+type ColumnAlias struct {
+	C  Col
+	AS string
+}
+
+func (ColumnAlias) IsCol() {}
+
+func (ca ColumnAlias) WriteSQLTo(st SQLWriter) error {
+	err := ca.C.WriteSQLTo(st)
+	if err != nil {
+		return err
+	}
+
+	_, err = st.WriteString(" AS ")
+	if err != nil {
+		return err
+	}
+	return writeAlias(st, ca.AS)
+}
+
+// QualifiedCol is select list element qualified by table already,
+// Prefix of ColumnList is not written before it.
+type QualifiedCol struct {
+	C Col
+}
+
+func (QualifiedCol) IsCol() {}
+
+func (qc QualifiedCol) WriteSQLTo(st SQLWriter) error {
+	return qc.C.WriteSQLTo(st)
+}
+
+func isPlainIdent(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, r := range s {
+		switch {
+		case r == '_', r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+		case i > 0 && r >= '0' && r <= '9':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
 type Arg struct {
 	V interface{}
 }
@@ -682,8 +816,7 @@ func (obe OrderByElem) WriteSQLTo(st SQLWriter) error {
 	if err != nil {
 		return err
 	}
-	_, err = st.WriteString(" " + string(obe.Kind))
-	return err
+	return writeStrings(st, " ", string(obe.Kind))
 }
 
 type OrderByStmt struct {
@@ -793,7 +926,7 @@ func (afc AggrFuncCall) Distinct() AggrFuncCall {
 func (AggrFuncCall) IsCol() {}
 
 func (fc AggrFuncCall) WriteSQLTo(st SQLWriter) error {
-	_, err := st.WriteString(fc.Name + "(")
+	err := writeStrings(st, fc.Name, "(")
 	if err != nil {
 		return err
 	}
@@ -923,8 +1056,7 @@ func (os OffsetStmt) WriteSQLTo(st SQLWriter) error {
 	if err != nil {
 		return err
 	}
-	_, err = st.WriteString(" " + strconv.FormatUint(os.V, 10))
-	return err
+	return writeStrings(st, " ", strconv.FormatUint(os.V, 10))
 }
 
 type LimitStmt struct {
@@ -940,6 +1072,119 @@ func (ls LimitStmt) WriteSQLTo(st SQLWriter) error {
 	if err != nil {
 		return err
 	}
-	_, err = st.WriteString(" " + strconv.FormatUint(ls.V, 10))
+	return writeStrings(st, " ", strconv.FormatUint(ls.V, 10))
+}
+
+// writeWithOffset writes MySQL form LIMIT offset, count.
+func (ls LimitStmt) writeWithOffset(st SQLWriter, os OffsetStmt) error {
+	err := LimitKeyword.WriteSQLTo(st)
+	if err != nil {
+		return err
+	}
+	return writeStrings(st, " ", strconv.FormatUint(os.V, 10), ", ", strconv.FormatUint(ls.V, 10))
+}
+
+type WhenClause struct {
+	When, Then Comparable
+}
+
+// CaseExpr is CASE [operand] WHEN ... THEN ... [ELSE ...] END.
+type CaseExpr struct {
+	Operand Comparable
+	Whens   []WhenClause
+	Else    Comparable
+}
+
+func (CaseExpr) IsCol()        {}
+func (CaseExpr) IsComparable() {}
+
+func (ce CaseExpr) WriteSQLTo(st SQLWriter) error {
+	if len(ce.Whens) == 0 {
+		return invalid(ce, "CASE must have at least one WHEN")
+	}
+
+	_, err := st.WriteString("CASE")
+	if err != nil {
+		return err
+	}
+
+	if ce.Operand != nil {
+		_, err = st.WriteString(" ")
+		if err != nil {
+			return err
+		}
+
+		err = ce.Operand.WriteSQLTo(st)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, wc := range ce.Whens {
+		_, err = st.WriteString(" WHEN ")
+		if err != nil {
+			return err
+		}
+
+		err = wc.When.WriteSQLTo(st)
+		if err != nil {
+			return err
+		}
+
+		_, err = st.WriteString(" THEN ")
+		if err != nil {
+			return err
+		}
+
+		err = wc.Then.WriteSQLTo(st)
+		if err != nil {
+			return err
+		}
+	}
+
+	if ce.Else != nil {
+		_, err = st.WriteString(" ELSE ")
+		if err != nil {
+			return err
+		}
+
+		err = ce.Else.WriteSQLTo(st)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = st.WriteString(" END")
 	return err
 }
+
+// CastExpr is CAST(A AS Type).
+type CastExpr struct {
+	A    Comparable
+	Type string
+}
+
+func Cast(a Comparable, typ string) CastExpr {
+	return CastExpr{
+		A:    a,
+		Type: typ,
+	}
+}
+
+func (CastExpr) IsCol()         {}
+func (CastExpr) IsComparable()  {}
+func (CastExpr) IsInsertValue() {}
+
+func (ce CastExpr) WriteSQLTo(st SQLWriter) error {
+	_, err := st.WriteString("CAST(")
+	if err != nil {
+		return err
+	}
+
+	err = ce.A.WriteSQLTo(st)
+	if err != nil {
+		return err
+	}
+
+	return writeStrings(st, " AS ", ce.Type, ")")
+}
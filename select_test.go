@@ -98,6 +98,7 @@ func TestWriteSQLTo(t *testing.T) {
 				t.Errorf("WriteSQLTo() raw SQL expected = %v, actual = %v", tt.expectedRawSQL, builded)
 			}
 			assert.Equal(t, tt.expectedArgs, tsw.Args)
+			assertRoundTrip(t, DefaultDialect{}, tt.sqb)
 		})
 	}
 }
@@ -130,6 +131,7 @@ func TestWriteSQLToPostgre(t *testing.T) {
 				t.Errorf("WriteSQLTo() raw SQL expected = %v, actual = %v", tt.expectedRawSQL, builded)
 			}
 			assert.Equal(t, tt.expectedArgs, tsw.Args)
+			assertRoundTrip(t, PostgreSQLDialect{}, tt.sqb)
 		})
 	}
 }
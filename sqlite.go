@@ -0,0 +1,63 @@
+package sqb
+
+import "strconv"
+
+// SQLiteDialect is SQLite with ?NNN placeholders.
+// Version is SQLITE_VERSION_NUMBER of target library, like 3035000 for 3.35.0,
+// constructs missing in older versions are refused. Zero means latest version.
+type SQLiteDialect struct {
+	Version int
+}
+
+// Versions of SQLite which introduced constructs.
+const (
+	sqliteUpsert     = 3024000
+	sqliteMaxArgs    = 3032000
+	sqliteUpdateFrom = 3033000
+	sqliteReturning  = 3035000
+	sqliteRightJoin  = 3039000
+)
+
+func (d SQLiteDialect) ToSQL(s SQB) (string, []interface{}, error) {
+	return toSQL(d, s)
+}
+
+// MaxArgs is default SQLITE_MAX_VARIABLE_NUMBER, which was 999 before 3.32.0.
+func (d SQLiteDialect) MaxArgs() int {
+	if d.before(sqliteMaxArgs) {
+		return 999
+	}
+	return 32766
+}
+
+func (SQLiteDialect) appendPlaceholder(b []byte, n int) []byte {
+	return strconv.AppendInt(append(b, '?'), int64(n), 10)
+}
+
+func (d SQLiteDialect) before(version int) bool {
+	return d.Version != 0 && d.Version < version
+}
+
+func isSQLite(w SQLWriter) bool {
+	_, ok := dialectOf(w).(SQLiteDialect)
+	return ok
+}
+
+// sqliteBefore reports whether w renders for SQLite older than version.
+func sqliteBefore(w SQLWriter, version int) bool {
+	d, ok := dialectOf(w).(SQLiteDialect)
+	return ok && d.before(version)
+}
+
+// sqliteVersion formats version number like 3035000 as 3.35.0.
+func sqliteVersion(version int) string {
+	return strconv.Itoa(version/1000000) + "." + strconv.Itoa(version/1000%1000) + "." + strconv.Itoa(version%1000)
+}
+
+// sqliteUnsupported reports construct introduced by SQLite version, nil if w renders for newer version.
+func sqliteUnsupported(w SQLWriter, node SQB, clause, feature string, version int) error {
+	if !sqliteBefore(w, version) {
+		return nil
+	}
+	return unsupported(w, node, clause, feature+" before "+sqliteVersion(version))
+}
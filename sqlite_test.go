@@ -0,0 +1,218 @@
+package sqb
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSQLiteDialect(t *testing.T) {
+	users := TableName("users")
+	upsert := Insert(users, []Column{"id", "name"}, InsertValuesStmt{{Arg{V: 1}, Arg{V: "alice"}}})
+	var tests = []struct {
+		name         string
+		dialect      Dialect
+		sqb          SQB
+		expectedSQL  string
+		expectedArgs []interface{}
+	}{
+		{
+			name:         "numbered placeholders",
+			dialect:      SQLiteDialect{},
+			sqb:          From(users).Where(Eq(Column("id"), Arg{V: 1}), Or(Eq(Column("owner_id"), Named("user", 2)), Eq(Column("editor_id"), Named("user", 2)))),
+			expectedSQL:  "SELECT * FROM users WHERE (id=?1) AND ((owner_id=?2) OR (editor_id=?2))",
+			expectedArgs: []interface{}{1, 2},
+		},
+		{
+			name:         "returning",
+			dialect:      SQLiteDialect{Version: 3035000},
+			sqb:          upsert.Returning(Column("id")),
+			expectedSQL:  "INSERT INTO users(id, name) VALUES (?1, ?2) RETURNING id",
+			expectedArgs: []interface{}{1, "alice"},
+		},
+		{
+			name:         "insert or ignore",
+			dialect:      SQLiteDialect{},
+			sqb:          upsert.Ignore(),
+			expectedSQL:  "INSERT OR IGNORE INTO users(id, name) VALUES (?1, ?2)",
+			expectedArgs: []interface{}{1, "alice"},
+		},
+		{
+			name:         "insert or replace",
+			dialect:      SQLiteDialect{Version: 3000000},
+			sqb:          upsert.Replace(),
+			expectedSQL:  "INSERT OR REPLACE INTO users(id, name) VALUES (?1, ?2)",
+			expectedArgs: []interface{}{1, "alice"},
+		},
+		{
+			name:         "on conflict do nothing",
+			dialect:      SQLiteDialect{Version: 3024000},
+			sqb:          upsert.OnConflictDoNothing(),
+			expectedSQL:  "INSERT INTO users(id, name) VALUES (?1, ?2) ON CONFLICT DO NOTHING",
+			expectedArgs: []interface{}{1, "alice"},
+		},
+		{
+			name:    "on conflict do update",
+			dialect: SQLiteDialect{},
+			sqb: upsert.OnConflictDoUpdate([]Column{"id"}, SetStmt{{Key: "name", Value: Excluded("name")}}, BinaryOp(Column("users.name"), "<>", Excluded("name"))).
+				Returning(Column("id")),
+			expectedSQL:  "INSERT INTO users(id, name) VALUES (?1, ?2) ON CONFLICT (id) DO UPDATE SET name = excluded.name WHERE (users.name <> excluded.name) RETURNING id",
+			expectedArgs: []interface{}{1, "alice"},
+		},
+		{
+			name:         "insert select on conflict",
+			dialect:      SQLiteDialect{},
+			sqb:          Insert(users, []Column{"id", "name"}, From(TableName("staged")).Select(Column("id"), Column("name"))).OnConflictDoNothing(),
+			expectedSQL:  "INSERT INTO users(id, name) SELECT id, name FROM staged WHERE (TRUE) ON CONFLICT DO NOTHING",
+			expectedArgs: nil,
+		},
+		{
+			name:         "postgresql on conflict",
+			dialect:      PostgreSQLDialect{},
+			sqb:          upsert.OnConflictDoUpdate([]Column{"id"}, SetStmt{{Key: "name", Value: Arg{V: "bob"}}}),
+			expectedSQL:  "INSERT INTO users(id, name) VALUES ($1, $2) ON CONFLICT (id) DO UPDATE SET name = $3",
+			expectedArgs: []interface{}{1, "alice", "bob"},
+		},
+		{
+			name:         "right join",
+			dialect:      SQLiteDialect{Version: 3039000},
+			sqb:          From(RightJoin(users, TableName("orders"), Eq(Column("orders.user_id"), Column("users.id")))),
+			expectedSQL:  "SELECT * FROM users RIGHT JOIN orders ON orders.user_id=users.id",
+			expectedArgs: nil,
+		},
+		{
+			name:    "update from",
+			dialect: SQLiteDialect{Version: 3033000},
+			sqb: UpdateStmt{
+				Table: users,
+				Set:   SetStmt{{Key: "total", Value: Column("o.total")}},
+				From:  UpdateFromStmt{Table: TableName("orders").As("o"), On: Eq(Column("o.user_id"), Column("users.id"))},
+			},
+			expectedSQL:  "UPDATE users SET total = o.total FROM orders AS o WHERE (o.user_id=users.id)",
+			expectedArgs: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args, err := tt.dialect.ToSQL(tt.sqb)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedSQL, query)
+			assert.Equal(t, tt.expectedArgs, args)
+			assertRoundTrip(t, tt.dialect, tt.sqb)
+		})
+	}
+}
+
+func TestSQLiteDialect_Unsupported(t *testing.T) {
+	users := TableName("users")
+	insert := Insert(users, []Column{"id"}, InsertValuesStmt{{Arg{V: 1}}})
+	old := SQLiteDialect{Version: 3022000}
+
+	tests := []struct {
+		name     string
+		dialect  Dialect
+		sqb      SQB
+		expected string
+	}{
+		{
+			name:     "returning",
+			dialect:  SQLiteDialect{Version: 3034001},
+			sqb:      insert.Returning(Column("id")),
+			expected: "sqb: SQLite does not support RETURNING before 3.35.0 (InsertStmt.ReturningStmt)",
+		},
+		{
+			name:     "update returning",
+			dialect:  old,
+			sqb:      UpdateStmt{Table: users, Set: SetStmt{{Key: "name", Value: Arg{V: "a"}}}}.Returning(Column("id")),
+			expected: "sqb: SQLite does not support RETURNING before 3.35.0 (UpdateStmt.ReturningStmt)",
+		},
+		{
+			name:     "on conflict",
+			dialect:  old,
+			sqb:      insert.OnConflictDoNothing(),
+			expected: "sqb: SQLite does not support ON CONFLICT before 3.24.0 (OnConflictStmt)",
+		},
+		{
+			name:     "mysql on conflict",
+			dialect:  MySQLDialect{},
+			sqb:      insert.OnConflictDoNothing(),
+			expected: "sqb: MySQL does not support ON CONFLICT (OnConflictStmt)",
+		},
+		{
+			name:     "right join",
+			dialect:  SQLiteDialect{Version: 3038005},
+			sqb:      From(RightJoin(users, TableName("orders"), Eq(Column("orders.user_id"), Column("users.id")))),
+			expected: "sqb: SQLite does not support RIGHT JOIN before 3.39.0 (RightJoinStmt)",
+		},
+		{
+			name:     "full join",
+			dialect:  old,
+			sqb:      From(FullOuterJoin(users, TableName("orders"), Eq(Column("orders.user_id"), Column("users.id")))),
+			expected: "sqb: SQLite does not support FULL OUTER JOIN before 3.39.0 (FullOuterJoinStmt)",
+		},
+		{
+			name:    "update from",
+			dialect: old,
+			sqb: UpdateStmt{
+				Table: users,
+				Set:   SetStmt{{Key: "total", Value: Column("o.total")}},
+				From:  UpdateFromStmt{Table: TableName("orders").As("o"), On: Eq(Column("o.user_id"), Column("users.id"))},
+			},
+			expected: "sqb: SQLite does not support UPDATE FROM before 3.33.0 (UpdateStmt.From)",
+		},
+		{
+			name:     "values column aliases",
+			dialect:  SQLiteDialect{},
+			sqb:      From(InsertValuesStmt{{Arg{V: 1}}}.As("v", "id")),
+			expected: "sqb: SQLite does not support column aliases of VALUES (ValuesTable.Columns)",
+		},
+		{
+			name:     "default value",
+			dialect:  SQLiteDialect{},
+			sqb:      Insert(users, []Column{"id", "name"}, InsertValuesStmt{{Arg{V: 1}, Default}}),
+			expected: "sqb: SQLite does not support DEFAULT (defaultWord)",
+		},
+		{
+			name:     "set default",
+			dialect:  SQLiteDialect{},
+			sqb:      UpdateStmt{Table: users, Set: SetStmt{{Key: "name", Value: defaultWord{}}}},
+			expected: "sqb: SQLite does not support DEFAULT (defaultWord)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := tt.dialect.ToSQL(tt.sqb)
+			assert.True(t, errors.Is(err, ErrUnsupported))
+			assert.EqualError(t, err, tt.expected)
+		})
+	}
+}
+
+func TestSQLiteDialect_MaxArgs(t *testing.T) {
+	assert.Equal(t, 32766, SQLiteDialect{}.MaxArgs())
+	assert.Equal(t, 32766, SQLiteDialect{Version: 3032000}.MaxArgs())
+	assert.Equal(t, 999, SQLiteDialect{Version: 3031001}.MaxArgs())
+
+	values := make(InsertValuesStmt, 0, 500)
+	for i := 0; i < 500; i++ {
+		values = append(values, []InsertValue{Arg{V: i}, Arg{V: i}})
+	}
+	queries, err := Insert(TableName("users"), []Column{"id", "name"}, values).Split(SQLiteDialect{Version: 3031001}, 0)
+	assert.NoError(t, err)
+	if assert.Len(t, queries, 2) {
+		assert.Len(t, queries[0].Args, 998)
+		assert.Len(t, queries[1].Args, 2)
+	}
+}
+
+func TestOnConflictStmt_Validate(t *testing.T) {
+	insert := Insert(TableName("users"), []Column{"id"}, InsertValuesStmt{{Arg{V: 1}}})
+	insert.OnConflictStmt = OnConflictStmt{DoNothing: true, Set: SetStmt{{Key: "id", Value: Arg{V: 2}}}}
+	err := Validate(insert)
+	assert.EqualError(t, err, "sqb: invalid statement: InsertStmt.OnConflictStmt: DO NOTHING with SET; InsertStmt.OnConflictStmt: DO UPDATE without conflict target")
+
+	insert.OnConflictStmt = OnConflictStmt{DoNothing: true, WhereStmt: WhereStmt{Exprs: []BoolExpr{Eq(Column("id"), Arg{V: 1})}}}
+	err = Validate(insert)
+	assert.EqualError(t, err, "sqb: invalid statement: InsertStmt.OnConflictStmt: WHERE without DO UPDATE")
+}
@@ -5,12 +5,29 @@ import (
 	"strings"
 )
 
+// Query is rendered statement with its arguments.
+type Query struct {
+	SQL  string
+	Args []interface{}
+}
+
 type SQLWriter interface {
 	AddArgs(interface{}) error
 	WriteString(string) (int, error)
 	AppendRawArgs(a ...interface{}) error
 }
 
+// writeStrings writes parts one by one, sparing their concatenation.
+func writeStrings(w SQLWriter, parts ...string) error {
+	for _, s := range parts {
+		_, err := w.WriteString(s)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 type DefaultSQLWriter struct {
 	strings.Builder
 	Args []interface{}
@@ -30,23 +47,27 @@ func (d *DefaultSQLWriter) AppendRawArgs(a ...interface{}) error {
 	return nil
 }
 
+func (d *DefaultSQLWriter) Dialect() Dialect {
+	return DefaultDialect{}
+}
+
 func ToSQL(s SQB) (string, []interface{}, error) {
-	st := &DefaultSQLWriter{}
-	err := s.WriteSQLTo(st)
-	if err != nil {
-		return "", nil, err
-	}
-	return st.Builder.String(), st.Args, nil
+	return toSQL(DefaultDialect{}, s)
 }
 
 type PostgreSQLWriter struct {
 	strings.Builder
 	Args []interface{}
+
+	names namedArgs
 }
 
 func (p *PostgreSQLWriter) AddArgs(a interface{}) error {
-	next := len(p.Args) + 1
-	_, err := p.WriteString(`$` + strconv.Itoa(next))
+	_, err := p.WriteString(`$`)
+	if err != nil {
+		return err
+	}
+	_, err = p.WriteString(strconv.Itoa(len(p.Args) + 1))
 	if err != nil {
 		return err
 	}
@@ -54,16 +75,57 @@ func (p *PostgreSQLWriter) AddArgs(a interface{}) error {
 	return nil
 }
 
+// AddNamedArg reuses placeholder of argument with the same name.
+func (p *PostgreSQLWriter) AddNamedArg(name string, v interface{}) error {
+	var placeholder [12]byte
+	b, args, _, err := p.names.bind(PostgreSQLDialect{}, placeholder[:0], p.Args, name, v)
+	if err != nil {
+		return err
+	}
+	_, err = p.Write(b)
+	if err != nil {
+		return err
+	}
+	p.Args = args
+	return nil
+}
+
 func (p *PostgreSQLWriter) AppendRawArgs(a ...interface{}) error {
 	p.Args = append(p.Args, a...)
 	return nil
 }
 
+func (p *PostgreSQLWriter) Dialect() Dialect {
+	return PostgreSQLDialect{}
+}
+
 func ToPostgreSql(s SQB) (string, []interface{}, error) {
-	st := &PostgreSQLWriter{}
-	err := s.WriteSQLTo(st)
+	return toSQL(PostgreSQLDialect{}, s)
+}
+
+type MySQLWriter struct {
+	strings.Builder
+	Args []interface{}
+}
+
+func (m *MySQLWriter) AddArgs(a interface{}) error {
+	_, err := m.WriteString(`?`)
 	if err != nil {
-		return "", nil, err
+		return err
 	}
-	return st.Builder.String(), st.Args, nil
+	m.Args = append(m.Args, a)
+	return nil
+}
+
+func (m *MySQLWriter) AppendRawArgs(a ...interface{}) error {
+	m.Args = append(m.Args, a...)
+	return nil
+}
+
+func (m *MySQLWriter) Dialect() Dialect {
+	return MySQLDialect{}
+}
+
+func ToMySQL(s SQB) (string, []interface{}, error) {
+	return toSQL(MySQLDialect{}, s)
 }
@@ -0,0 +1,113 @@
+package sqb
+
+import "io"
+
+// StreamWriter is SQLWriter of dialect D writing SQL to W as it is rendered,
+// so scripts of any size are generated without holding them in memory.
+// Arguments are collected to Args, or written as literals when Inline is set.
+// Each statement of WriteStatement is numbered from first placeholder
+// and Args holds only its arguments, so they must be taken after every call.
+// Writes are not buffered, wrap W with bufio.Writer for large outputs.
+type StreamWriter struct {
+	W      io.Writer
+	D      Dialect
+	Inline bool
+	Args   []interface{}
+
+	// N is number of bytes written to W.
+	N       int64
+	scratch [24]byte
+	names   namedArgs
+}
+
+func (sw *StreamWriter) WriteString(s string) (int, error) {
+	n, err := io.WriteString(sw.W, s)
+	sw.N += int64(n)
+	return n, err
+}
+
+func (sw *StreamWriter) Write(p []byte) (int, error) {
+	n, err := sw.W.Write(p)
+	sw.N += int64(n)
+	return n, err
+}
+
+func (sw *StreamWriter) AddArgs(a interface{}) error {
+	if sw.Inline {
+		lit, err := literal(sw.Dialect(), a)
+		if err != nil {
+			return err
+		}
+		_, err = sw.WriteString(lit)
+		return err
+	}
+	_, err := sw.Write(appendPlaceholder(sw.D, sw.scratch[:0], len(sw.Args)+1))
+	if err != nil {
+		return err
+	}
+	sw.Args = append(sw.Args, a)
+	return nil
+}
+
+func (sw *StreamWriter) AddNamedArg(name string, v interface{}) error {
+	d := sw.D
+	if sw.Inline {
+		// interpolated values are bound by position
+		d = DefaultDialect{}
+	}
+	b, args, ok, err := sw.names.bind(d, sw.scratch[:0], sw.Args, name, v)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return sw.AddArgs(v)
+	}
+	_, err = sw.Write(b)
+	if err != nil {
+		return err
+	}
+	sw.Args = args
+	return nil
+}
+
+// AppendRawArgs fails for Inline writer because placeholders of raw SQL are already written.
+func (sw *StreamWriter) AppendRawArgs(a ...interface{}) error {
+	if !sw.Inline {
+		sw.Args = append(sw.Args, a...)
+		return nil
+	}
+	if len(a) == 0 {
+		return nil
+	}
+	return &UnsupportedError{Dialect: sw.Dialect(), Node: "RawSQL", Feature: "interpolation of raw SQL arguments"}
+}
+
+func (sw *StreamWriter) Dialect() Dialect {
+	if sw.D == nil {
+		return DefaultDialect{}
+	}
+	return sw.D
+}
+
+// Len is number of bytes written, PrettyWriter uses it to track column.
+func (sw *StreamWriter) Len() int {
+	return int(sw.N)
+}
+
+// WriteStatement validates and writes s terminated by semicolon and newline,
+// Args are replaced by arguments of s.
+func (sw *StreamWriter) WriteStatement(s SQB) error {
+	err := Validate(s)
+	if err != nil {
+		return err
+	}
+	// statements do not share placeholders nor arguments
+	sw.Args = nil
+	sw.names.reset()
+	err = s.WriteSQLTo(sw)
+	if err != nil {
+		return err
+	}
+	_, err = sw.WriteString(";\n")
+	return err
+}
@@ -0,0 +1,89 @@
+package sqb
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type limitedWriter struct {
+	bytes.Buffer
+	limit int
+}
+
+func (lw *limitedWriter) Write(p []byte) (int, error) {
+	if lw.Len()+len(p) > lw.limit {
+		return 0, errors.New("disk full")
+	}
+	return lw.Buffer.Write(p)
+}
+
+func TestStreamWriter(t *testing.T) {
+	users := TableName("users")
+	insert := func(id int, name string) SQB {
+		return Insert(users, []Column{"id", "name"}, InsertValuesStmt{{Arg{V: id}, Arg{V: name}}})
+	}
+
+	t.Run("args", func(t *testing.T) {
+		var out bytes.Buffer
+		sw := &StreamWriter{W: &out, D: PostgreSQLDialect{}}
+		assert.NoError(t, sw.WriteStatement(insert(1, "alice")))
+		first := sw.Args
+		assert.NoError(t, sw.WriteStatement(insert(2, "bob")))
+		assert.Equal(t, "INSERT INTO users(id, name) VALUES ($1, $2);\nINSERT INTO users(id, name) VALUES ($1, $2);\n", out.String())
+		assert.Equal(t, []interface{}{1, "alice"}, first)
+		assert.Equal(t, []interface{}{2, "bob"}, sw.Args)
+		assert.Equal(t, int64(out.Len()), sw.N)
+	})
+
+	t.Run("named args", func(t *testing.T) {
+		var out bytes.Buffer
+		sw := &StreamWriter{W: &out, D: SQLServerDialect{}}
+		byName := func(name string) SQB {
+			return From(users).Where(Eq(Column("name"), Named("name", name)), Eq(Column("id"), Arg{V: 1}))
+		}
+		assert.NoError(t, sw.WriteStatement(byName("alice")))
+		assert.Equal(t, []interface{}{sql.Named("name", "alice"), 1}, sw.Args)
+		assert.NoError(t, sw.WriteStatement(byName("bob")))
+		assert.Equal(t, []interface{}{sql.Named("name", "bob"), 1}, sw.Args)
+		assert.Equal(t, "SELECT * FROM users WHERE (name=@name) AND (id=@p2);\nSELECT * FROM users WHERE (name=@name) AND (id=@p2);\n", out.String())
+	})
+
+	t.Run("inline", func(t *testing.T) {
+		var out bytes.Buffer
+		sw := &StreamWriter{W: &out, D: MySQLDialect{}, Inline: true}
+		assert.NoError(t, sw.WriteStatement(insert(1, "o'neil")))
+		assert.Equal(t, "INSERT INTO users(id, name) VALUES (1, 'o\\'neil');\n", out.String())
+		assert.Empty(t, sw.Args)
+
+		err := sw.WriteStatement(From(users).Where(Raw("id = ?", 1)))
+		assert.True(t, errors.Is(err, ErrUnsupported))
+	})
+
+	t.Run("pretty", func(t *testing.T) {
+		var out bytes.Buffer
+		sw := &StreamWriter{W: &out, D: PostgreSQLDialect{}}
+		err := From(users).Select(Column("id"), Column("name")).Where(Eq(Column("id"), Arg{V: 1})).WriteSQLTo(Pretty(sw))
+		assert.NoError(t, err)
+		assert.Equal(t, "SELECT id,\n       name\nFROM users\nWHERE (id=$1)", out.String())
+	})
+
+	t.Run("write error", func(t *testing.T) {
+		out := &limitedWriter{limit: 20}
+		sw := &StreamWriter{W: out}
+		err := sw.WriteStatement(insert(1, "alice"))
+		assert.EqualError(t, err, "disk full")
+		assert.Equal(t, int64(out.Len()), sw.N)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		var out bytes.Buffer
+		sw := &StreamWriter{W: &out}
+		err := sw.WriteStatement(SelectStmt{})
+		assert.True(t, errors.Is(err, ErrInvalidStatement))
+		assert.Zero(t, out.Len())
+	})
+}
@@ -0,0 +1,277 @@
+package sqb
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// TagName is struct tag key holding column name and options:
+//
+//	ID      int64     `db:"id,pk,default"`
+//	Name    string    `db:"name"`
+//	Bio     string    `db:"bio,omitempty"`
+//	Created time.Time `db:"created_at,readonly"`
+//
+// Options are
+// pk - primary key, used in WHERE of UpdateStruct and never SET;
+// default - zero value is written as DEFAULT;
+// omitempty - zero value is skipped;
+// readonly - never inserted nor updated.
+//
+// Embedded structs are flattened. Embedded struct with tag name
+// maps its fields to columns prefixed with tag name and dot,
+// which is useful for joined tables.
+const TagName = "db"
+
+type structField struct {
+	name       string
+	index      []int
+	nested     bool
+	primaryKey bool
+	useDefault bool
+	omitEmpty  bool
+	readOnly   bool
+}
+
+var structFieldsCache sync.Map
+
+func structFields(t reflect.Type) []structField {
+	if cached, ok := structFieldsCache.Load(t); ok {
+		return cached.([]structField)
+	}
+	fields := appendStructFields(nil, t, nil, "")
+	structFieldsCache.Store(t, fields)
+	return fields
+}
+
+func appendStructFields(fields []structField, t reflect.Type, index []int, prefix string) []structField {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag, hasTag := f.Tag.Lookup(TagName)
+		if tag == "-" {
+			continue
+		}
+
+		name, opts := tag, ""
+		if comma := strings.IndexByte(tag, ','); comma >= 0 {
+			name, opts = tag[:comma], tag[comma+1:]
+		}
+
+		fieldIndex := make([]int, len(index)+1)
+		copy(fieldIndex, index)
+		fieldIndex[len(index)] = i
+
+		ft := f.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if f.Anonymous && ft.Kind() == reflect.Struct {
+			nestedPrefix := prefix
+			if name != "" {
+				nestedPrefix = prefix + name + "."
+			}
+			fields = appendStructFields(fields, ft, fieldIndex, nestedPrefix)
+			continue
+		}
+
+		if !hasTag || name == "" || f.PkgPath != "" {
+			continue
+		}
+
+		sf := structField{
+			name:   prefix + name,
+			index:  fieldIndex,
+			nested: prefix != "",
+		}
+		for _, opt := range strings.Split(opts, ",") {
+			switch opt {
+			case "pk":
+				sf.primaryKey = true
+			case "default":
+				sf.useDefault = true
+			case "omitempty":
+				sf.omitEmpty = true
+			case "readonly":
+				sf.readOnly = true
+			}
+		}
+		fields = append(fields, sf)
+	}
+	return fields
+}
+
+// fieldValue returns field of struct value v, false if field is reached through nil pointer.
+func fieldValue(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+func structValue(v interface{}) (reflect.Value, error) {
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return reflect.Value{}, errors.New("sqb: nil struct pointer")
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("sqb: expected struct, got %T", v)
+	}
+	return rv, nil
+}
+
+func isZeroField(v reflect.Value, ok bool) bool {
+	return !ok || v.IsZero()
+}
+
+func fieldInterface(v reflect.Value, ok bool) interface{} {
+	if !ok {
+		return nil
+	}
+	return v.Interface()
+}
+
+// InsertStructs builds insert of rows, which must be structs of same type.
+// Column of omitempty field is skipped when it is zero in all rows,
+// otherwise its zero values are written as DEFAULT, which SQLite does not support.
+func InsertStructs(table TableIdentifier, rows ...interface{}) (InsertStmt, error) {
+	if len(rows) == 0 {
+		return InsertStmt{}, errors.New("sqb: no rows to insert")
+	}
+
+	values := make([]reflect.Value, 0, len(rows))
+	for _, row := range rows {
+		rv, err := structValue(row)
+		if err != nil {
+			return InsertStmt{}, err
+		}
+		if len(values) > 0 && rv.Type() != values[0].Type() {
+			return InsertStmt{}, fmt.Errorf("sqb: rows of different types %s and %s", values[0].Type(), rv.Type())
+		}
+		values = append(values, rv)
+	}
+
+	var (
+		columns []Column
+		used    []structField
+	)
+	for _, f := range structFields(values[0].Type()) {
+		if f.readOnly || f.nested {
+			continue
+		}
+		if f.omitEmpty && allZero(values, f.index) {
+			continue
+		}
+		columns = append(columns, Column(f.name))
+		used = append(used, f)
+	}
+
+	if len(columns) == 0 {
+		return InsertStmt{}, fmt.Errorf("sqb: no columns to insert in %s", values[0].Type())
+	}
+
+	source := make(InsertValuesStmt, 0, len(values))
+	for _, rv := range values {
+		line := make([]InsertValue, 0, len(used))
+		for _, f := range used {
+			fv, ok := fieldValue(rv, f.index)
+			if (f.useDefault || f.omitEmpty) && isZeroField(fv, ok) {
+				line = append(line, Default)
+				continue
+			}
+			line = append(line, Arg{V: fieldInterface(fv, ok)})
+		}
+		source = append(source, line)
+	}
+
+	return Insert(table, columns, source), nil
+}
+
+func allZero(values []reflect.Value, index []int) bool {
+	for _, rv := range values {
+		if !isZeroField(fieldValue(rv, index)) {
+			return false
+		}
+	}
+	return true
+}
+
+// UpdateStruct builds update of row identified by pk fields of v.
+// Zero default fields are set to DEFAULT, zero omitempty fields are skipped.
+func UpdateStruct(table TableIdentifier, v interface{}) (UpdateStmt, error) {
+	rv, err := structValue(v)
+	if err != nil {
+		return UpdateStmt{}, err
+	}
+
+	us := UpdateStmt{Table: table}
+	for _, f := range structFields(rv.Type()) {
+		if f.readOnly || f.nested {
+			continue
+		}
+
+		fv, ok := fieldValue(rv, f.index)
+		if f.primaryKey {
+			us.WhereStmt.Exprs = append(us.WhereStmt.Exprs, Eq(Column(f.name), Arg{V: fieldInterface(fv, ok)}))
+			continue
+		}
+
+		zero := isZeroField(fv, ok)
+		switch {
+		case f.omitEmpty && zero:
+		case f.useDefault && zero:
+			us.Set = append(us.Set, SetArg{Key: Column(f.name), Value: defaultWord{}})
+		default:
+			us.Set = append(us.Set, SetArg{Key: Column(f.name), Value: Arg{V: fieldInterface(fv, ok)}})
+		}
+	}
+
+	if us.WhereStmt.Empty() {
+		return UpdateStmt{}, fmt.Errorf("sqb: no pk fields in %s", rv.Type())
+	}
+	if len(us.Set) == 0 {
+		return UpdateStmt{}, fmt.Errorf("sqb: no columns to update in %s", rv.Type())
+	}
+	return us, nil
+}
+
+// StructColumns returns select list of tagged fields of struct v,
+// which may be struct value, pointer to struct or reflect.Type of them.
+// Fields of embedded struct with tag name are selected with alias
+// equal to prefixed name, so they can be scanned back into the struct;
+// they are QualifiedCol, so prefix of WithPrefix does not apply to them.
+// StructColumns panics if v is not struct.
+func StructColumns(v interface{}) ColumnList {
+	t, ok := v.(reflect.Type)
+	if !ok {
+		t = reflect.TypeOf(v)
+	}
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		panic(fmt.Sprintf("sqb: StructColumns of non-struct type %v", t))
+	}
+
+	fields := structFields(t)
+	cols := make([]Col, 0, len(fields))
+	for _, f := range fields {
+		if f.nested {
+			cols = append(cols, QualifiedCol{C: Column(f.name).As(f.name)})
+			continue
+		}
+		cols = append(cols, Column(f.name))
+	}
+	return NewColumnList(cols...)
+}
@@ -0,0 +1,188 @@
+package sqb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testTimestamps struct {
+	CreatedAt time.Time `db:"created_at,readonly"`
+	UpdatedAt time.Time `db:"updated_at,default"`
+}
+
+type testUser struct {
+	ID   int64  `db:"id,pk,default"`
+	Name string `db:"name"`
+	Bio  string `db:"bio,omitempty"`
+	testTimestamps
+	Ignored string `db:"-"`
+	NoTag   string
+}
+
+func TestInsertStructs(t *testing.T) {
+	updated := time.Date(2020, 05, 14, 13, 32, 00, 00, time.UTC)
+	tests := []struct {
+		name           string
+		rows           []interface{}
+		wantErr        bool
+		expectedRawSQL string
+		expectedArgs   []interface{}
+	}{
+		{
+			name:           "zero values",
+			rows:           []interface{}{testUser{Name: "alice"}},
+			expectedRawSQL: "INSERT INTO users(id, name, updated_at) VALUES (DEFAULT, ?, DEFAULT)",
+			expectedArgs:   []interface{}{"alice"},
+		},
+		{
+			name: "several rows",
+			rows: []interface{}{
+				&testUser{ID: 1, Name: "alice", Bio: "hi"},
+				testUser{ID: 2, Name: "bob", testTimestamps: testTimestamps{UpdatedAt: updated}},
+			},
+			expectedRawSQL: "INSERT INTO users(id, name, bio, updated_at) VALUES (?, ?, ?, DEFAULT), (?, ?, DEFAULT, ?)",
+			expectedArgs:   []interface{}{int64(1), "alice", "hi", int64(2), "bob", updated},
+		},
+		{
+			name:    "different types",
+			rows:    []interface{}{testUser{}, testTimestamps{}},
+			wantErr: true,
+		},
+		{
+			name:    "not a struct",
+			rows:    []interface{}{1},
+			wantErr: true,
+		},
+		{
+			name:    "no rows",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			is, err := InsertStructs(TableName("users"), tt.rows...)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("InsertStructs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			builded, args, err := ToSQL(is)
+			assert.NoError(t, err)
+			if builded != tt.expectedRawSQL {
+				t.Errorf("WriteSQLTo() raw SQL expected = %v, actual = %v", tt.expectedRawSQL, builded)
+			}
+			assert.Equal(t, tt.expectedArgs, args)
+			assertRoundTrip(t, DefaultDialect{}, is)
+		})
+	}
+}
+
+func TestUpdateStruct(t *testing.T) {
+	tests := []struct {
+		name           string
+		v              interface{}
+		wantErr        bool
+		expectedRawSQL string
+		expectedArgs   []interface{}
+	}{
+		{
+			name:           "skip omitempty",
+			v:              testUser{ID: 1, Name: "alice"},
+			expectedRawSQL: "UPDATE users SET name = ?, updated_at = DEFAULT WHERE (id=?)",
+			expectedArgs:   []interface{}{"alice", int64(1)},
+		},
+		{
+			name:           "all values",
+			v:              &testUser{ID: 1, Name: "alice", Bio: "hi"},
+			expectedRawSQL: "UPDATE users SET name = ?, bio = ?, updated_at = DEFAULT WHERE (id=?)",
+			expectedArgs:   []interface{}{"alice", "hi", int64(1)},
+		},
+		{
+			name:    "no primary key",
+			v:       testTimestamps{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			us, err := UpdateStruct(TableName("users"), tt.v)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("UpdateStruct() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			builded, args, err := ToSQL(us)
+			assert.NoError(t, err)
+			if builded != tt.expectedRawSQL {
+				t.Errorf("WriteSQLTo() raw SQL expected = %v, actual = %v", tt.expectedRawSQL, builded)
+			}
+			assert.Equal(t, tt.expectedArgs, args)
+			assertRoundTrip(t, DefaultDialect{}, us)
+		})
+	}
+}
+
+type testPost struct {
+	ID    int64  `db:"id"`
+	Title string `db:"title"`
+}
+
+type testUserPost struct {
+	testUser `db:"u"`
+	testPost `db:"p"`
+	Rank     int `db:"rank"`
+}
+
+func TestStructColumns(t *testing.T) {
+	tests := []struct {
+		name           string
+		sqb            SQB
+		expectedRawSQL string
+	}{
+		{
+			name:           "flat struct",
+			sqb:            From(TableName("users")).SelectList(StructColumns(testUser{})),
+			expectedRawSQL: "SELECT id, name, bio, created_at, updated_at FROM users",
+		},
+		{
+			name:           "with prefix",
+			sqb:            From(TableName("users").As("u")).SelectList(StructColumns((*testPost)(nil)).WithPrefix("u")),
+			expectedRawSQL: "SELECT u.id, u.title FROM users AS u",
+		},
+		{
+			name: "embedded structs of joined tables",
+			sqb: From(
+				JB(TableName("users").As("u")).InnerJoin(TableName("posts").As("p"), Eq(Column("u.id"), Column("p.user_id"))),
+			).SelectList(StructColumns(&testUserPost{})),
+			expectedRawSQL: `SELECT u.id AS "u.id", u.name AS "u.name", u.bio AS "u.bio", u.created_at AS "u.created_at", u.updated_at AS "u.updated_at", p.id AS "p.id", p.title AS "p.title", rank FROM users AS u INNER JOIN posts AS p ON u.id=p.user_id`,
+		},
+		{
+			name: "prefix does not apply to embedded structs",
+			sqb: From(
+				JB(TableName("users").As("u")).InnerJoin(TableName("posts").As("p"), Eq(Column("u.id"), Column("p.user_id"))),
+			).SelectList(StructColumns(&testUserPost{}).WithPrefix("p")),
+			expectedRawSQL: `SELECT u.id AS "u.id", u.name AS "u.name", u.bio AS "u.bio", u.created_at AS "u.created_at", u.updated_at AS "u.updated_at", p.id AS "p.id", p.title AS "p.title", p.rank FROM users AS u INNER JOIN posts AS p ON u.id=p.user_id`,
+		},
+		{
+			name:           "prefix applies to dotted names of plain list",
+			sqb:            From(TableName("users").As("u")).SelectList(NewColumnList(Column("address.city"), Column("id").As("u.id")).WithPrefix("u")),
+			expectedRawSQL: `SELECT u.address.city, u.id AS "u.id" FROM users AS u`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			builded, _, err := ToSQL(tt.sqb)
+			assert.NoError(t, err)
+			if builded != tt.expectedRawSQL {
+				t.Errorf("WriteSQLTo() raw SQL expected = %v, actual = %v", tt.expectedRawSQL, builded)
+			}
+			assertRoundTrip(t, DefaultDialect{}, tt.sqb)
+		})
+	}
+
+	assert.Panics(t, func() { StructColumns(1) })
+}
@@ -0,0 +1,113 @@
+package sqb
+
+// Typed is expression holding values of type T.
+// Eq, BinaryOp and In accept operands of any types, so typed expressions are
+// compared by EqOf, LtOf, InOf and the like or by methods of TypedColumn,
+// which accept only operands of the same type.
+type Typed[T any] interface {
+	Comparable
+	Col
+	typed(T)
+}
+
+// TypedColumn is column holding values of type T.
+// Its comparison methods accept only expressions of the same type:
+//
+//	id := ColumnOf[int64]("id")
+//	id.Eq(ArgOf(int64(1)))  // ok
+//	id.Eq(ArgOf("x"))       // does not compile
+type TypedColumn[T any] struct {
+	Column
+}
+
+func ColumnOf[T any](name string) TypedColumn[T] {
+	return TypedColumn[T]{Column: Column(name)}
+}
+
+func (TypedColumn[T]) typed(T) {}
+
+func (tc TypedColumn[T]) Eq(v Typed[T]) EqExpr {
+	return EqOf[T](tc, v)
+}
+
+func (tc TypedColumn[T]) NotEq(v Typed[T]) BinaryOperator {
+	return NotEqOf[T](tc, v)
+}
+
+func (tc TypedColumn[T]) Lt(v Typed[T]) BinaryOperator {
+	return LtOf[T](tc, v)
+}
+
+func (tc TypedColumn[T]) Lte(v Typed[T]) BinaryOperator {
+	return LteOf[T](tc, v)
+}
+
+func (tc TypedColumn[T]) Gt(v Typed[T]) BinaryOperator {
+	return GtOf[T](tc, v)
+}
+
+func (tc TypedColumn[T]) Gte(v Typed[T]) BinaryOperator {
+	return GteOf[T](tc, v)
+}
+
+func (tc TypedColumn[T]) In(vs ...Typed[T]) InExpr {
+	return InOf[T](tc, vs...)
+}
+
+func (tc TypedColumn[T]) IsNull() NullCheck {
+	return NullCheck{A: tc, IsNull: true}
+}
+
+func (tc TypedColumn[T]) IsNotNull() NullCheck {
+	return NullCheck{A: tc}
+}
+
+// Set is assignment of v to column for UpdateStmt.
+func (tc TypedColumn[T]) Set(v Typed[T]) SetArg {
+	return SetArg{Key: tc.Column, Value: v}
+}
+
+// TypedArg is argument of type T.
+type TypedArg[T any] struct {
+	Arg
+}
+
+func ArgOf[T any](v T) TypedArg[T] {
+	return TypedArg[T]{Arg: Arg{V: v}}
+}
+
+func (TypedArg[T]) typed(T) {}
+
+// EqOf is Eq of operands of the same type.
+func EqOf[T any](a, b Typed[T]) EqExpr {
+	return Eq(a, b)
+}
+
+func NotEqOf[T any](a, b Typed[T]) BinaryOperator {
+	return BinaryOp(a, "<>", b)
+}
+
+func LtOf[T any](a, b Typed[T]) BinaryOperator {
+	return BinaryOp(a, "<", b)
+}
+
+func LteOf[T any](a, b Typed[T]) BinaryOperator {
+	return BinaryOp(a, "<=", b)
+}
+
+func GtOf[T any](a, b Typed[T]) BinaryOperator {
+	return BinaryOp(a, ">", b)
+}
+
+func GteOf[T any](a, b Typed[T]) BinaryOperator {
+	return BinaryOp(a, ">=", b)
+}
+
+// InOf is In of operand and list of the same type.
+func InOf[T any](a Typed[T], list ...Typed[T]) InExpr {
+	l := make([]Comparable, 0, len(list))
+	for _, v := range list {
+		l = append(l, v)
+	}
+	return In(a, l...)
+}
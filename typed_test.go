@@ -0,0 +1,121 @@
+package sqb
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTypedColumn_WriteSQLTo(t *testing.T) {
+	id := ColumnOf[int64]("id")
+	name := ColumnOf[string]("name")
+	var tests = []struct {
+		name           string
+		sqb            SQB
+		wantErr        bool
+		expectedRawSQL string
+		expectedArgs   []interface{}
+	}{
+		{
+			name:           "eq",
+			expectedRawSQL: "SELECT id, name FROM users WHERE (id=?) AND (name IS NOT NULL)",
+			expectedArgs:   []interface{}{int64(1)},
+			sqb:            From(TableName("users")).Select(id, name).Where(id.Eq(ArgOf[int64](1)), name.IsNotNull()),
+		},
+		{
+			name:           "compare columns",
+			expectedRawSQL: "SELECT * FROM users WHERE (id >= parent_id) AND (id IN (?, ?))",
+			expectedArgs:   []interface{}{int64(1), int64(2)},
+			sqb: From(TableName("users")).Where(
+				id.Gte(ColumnOf[int64]("parent_id")),
+				id.In(ArgOf[int64](1), ArgOf[int64](2)),
+			),
+		},
+		{
+			name:           "generic comparisons",
+			expectedRawSQL: "SELECT * FROM users WHERE (? < id) AND (name <> ?) AND (? IN (id, parent_id))",
+			expectedArgs:   []interface{}{int64(10), "bob", int64(3)},
+			sqb: From(TableName("users")).Where(
+				LtOf[int64](ArgOf[int64](10), id),
+				NotEqOf[string](name, ArgOf("bob")),
+				InOf[int64](ArgOf[int64](3), id, ColumnOf[int64]("parent_id")),
+			),
+		},
+		{
+			name:           "set",
+			expectedRawSQL: "UPDATE users SET name = ? WHERE (id=?)",
+			expectedArgs:   []interface{}{"alice", int64(1)},
+			sqb: UpdateStmt{
+				Table:     TableName("users"),
+				Set:       SetStmt{name.Set(ArgOf("alice"))},
+				WhereStmt: WhereStmt{Exprs: []BoolExpr{id.Eq(ArgOf[int64](1))}},
+			},
+		},
+		{
+			name:           "insert typed args",
+			expectedRawSQL: "INSERT INTO users(id, name) VALUES (?, ?)",
+			expectedArgs:   []interface{}{int64(1), "alice"},
+			sqb:            Insert(TableName("users"), []Column{id.Column, name.Column}, InsertValuesStmt{{ArgOf[int64](1), ArgOf("alice")}}),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqb := tt.sqb
+			tsw := &DefaultSQLWriter{}
+			if err := sqb.WriteSQLTo(tsw); (err != nil) != tt.wantErr {
+				t.Errorf("WriteSQLTo() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			builded := tsw.String()
+			if builded != tt.expectedRawSQL {
+				t.Errorf("WriteSQLTo() raw SQL expected = %v, actual = %v", tt.expectedRawSQL, builded)
+			}
+			assert.Equal(t, tt.expectedArgs, tsw.Args)
+			assertRoundTrip(t, DefaultDialect{}, tt.sqb)
+		})
+	}
+}
+
+func TestTypedColumn_MismatchDoesNotCompile(t *testing.T) {
+	if testing.Short() {
+		t.Skip("type checks package from source")
+	}
+
+	const src = `package p
+
+import "github.com/vagruchi/sqb"
+
+var id = sqb.ColumnOf[int64]("id")
+
+var ok = id.Eq(sqb.ArgOf(int64(1)))
+
+var bad = id.Eq(sqb.ArgOf("x"))
+
+var badLt = id.Lt(sqb.ArgOf(1.5))
+
+var badIn = id.In(sqb.ArgOf(int64(1)), sqb.ArgOf("x"))
+
+var badGtOf = sqb.GtOf[int64](sqb.ArgOf("x"), id)
+
+var okInOf = sqb.InOf[int64](sqb.ArgOf(int64(1)), id)
+`
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, "p.go", src, 0)
+	assert.NoError(t, err)
+
+	var errs []types.Error
+	conf := types.Config{
+		Importer: importer.ForCompiler(fset, "source", nil),
+		Error:    func(err error) { errs = append(errs, err.(types.Error)) },
+	}
+	_, _ = conf.Check("p", fset, []*ast.File{f}, nil)
+	var lines []int
+	for _, e := range errs {
+		lines = append(lines, fset.Position(e.Pos).Line)
+	}
+	assert.Equal(t, []int{9, 11, 13, 15}, lines)
+}
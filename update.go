@@ -31,17 +31,14 @@ func (ss SetStmt) WriteSQLTo(w SQLWriter) error {
 		return err
 	}
 
+	beginList(w)
 	err = ss[0].WriteSQLTo(w)
 	if err != nil {
 		return err
 	}
 
-	if len(ss) == 1 {
-		return nil
-	}
-
 	for _, s := range ss[1:] {
-		_, err = w.WriteString(", ")
+		err = writeListSep(w)
 		if err != nil {
 			return err
 		}
@@ -51,19 +48,38 @@ func (ss SetStmt) WriteSQLTo(w SQLWriter) error {
 			return err
 		}
 	}
+	endList(w)
 
 	return nil
 }
 
+// UpdateFromStmt is source tables of multi-table update.
+// PostgreSQL renders it as FROM with On moved to WHERE,
+// MySQL renders it as JOIN ... ON before SET.
+type UpdateFromStmt struct {
+	Table Joinable
+	On    OnExpr
+}
+
+func (ufs UpdateFromStmt) Empty() bool {
+	return ufs.Table == nil
+}
+
 type UpdateStmt struct {
 	Table         TableIdentifier
 	Set           SetStmt
+	From          UpdateFromStmt
 	WhereStmt     WhereStmt
 	ReturningStmt ReturningStmt
 }
 
 func (us UpdateStmt) WriteSQLTo(w SQLWriter) error {
-	_, err := w.WriteString(`UPDATE `)
+	err := beginStmt(w)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.WriteString(`UPDATE `)
 	if err != nil {
 		return err
 	}
@@ -73,7 +89,15 @@ func (us UpdateStmt) WriteSQLTo(w SQLWriter) error {
 		return err
 	}
 
-	_, err = w.WriteString(` `)
+	joinFrom := isMySQL(w)
+	if joinFrom && !us.From.Empty() {
+		err = us.writeJoin(w)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = writeClause(w)
 	if err != nil {
 		return err
 	}
@@ -83,25 +107,91 @@ func (us UpdateStmt) WriteSQLTo(w SQLWriter) error {
 		return err
 	}
 
-	if !us.WhereStmt.Empty() {
-		_, err = w.WriteString(` `)
+	where := us.WhereStmt
+	if !joinFrom && !us.From.Empty() {
+		if err = sqliteUnsupported(w, us, "From", "UPDATE FROM", sqliteUpdateFrom); err != nil {
+			return err
+		}
+
+		err = writeClause(w)
+		if err != nil {
+			return err
+		}
+
+		_, err = w.WriteString(`FROM `)
+		if err != nil {
+			return err
+		}
+
+		err = us.From.Table.WriteSQLTo(w)
+		if err != nil {
+			return err
+		}
+
+		if us.From.On != nil {
+			where.Exprs = append([]BoolExpr{us.From.On}, where.Exprs...)
+		}
+	}
+
+	if !where.Empty() {
+		err = writeClause(w)
 		if err != nil {
 			return err
 		}
 
-		err = us.WhereStmt.WriteSQLTo(w)
+		err = where.WriteSQLTo(w)
 		if err != nil {
 			return err
 		}
 	}
 	// must be last statement
 	if us.ReturningStmt.Cols != nil {
+		if isMySQL(w) {
+			return unsupported(w, us, "ReturningStmt", "RETURNING")
+		}
+		if err = sqliteUnsupported(w, us, "ReturningStmt", "RETURNING", sqliteReturning); err != nil {
+			return err
+		}
 		err = us.ReturningStmt.WriteSQLTo(w)
 		if err != nil {
 			return err
 		}
 	}
-	return nil
+	return endStmt(w)
+}
+
+func (us UpdateStmt) writeJoin(w SQLWriter) error {
+	if us.From.On == nil {
+		err := writeClause(w)
+		if err != nil {
+			return err
+		}
+		_, err = w.WriteString(`CROSS JOIN `)
+		if err != nil {
+			return err
+		}
+		return us.From.Table.WriteSQLTo(w)
+	}
+
+	err := writeClause(w)
+	if err != nil {
+		return err
+	}
+	_, err = w.WriteString(`INNER JOIN `)
+	if err != nil {
+		return err
+	}
+
+	err = us.From.Table.WriteSQLTo(w)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.WriteString(` ON `)
+	if err != nil {
+		return err
+	}
+	return us.From.On.WriteSQLTo(w)
 }
 
 func (us UpdateStmt) Returning(cc ...Col) UpdateStmt {
@@ -29,6 +29,34 @@ func TestUpdateStmt_WriteSQLTo(t *testing.T) {
 			expectedRawSQL: "UPDATE users SET updated_at = ?",
 			expectedArgs:   []interface{}{time.Date(2020, 05, 14, 13, 32, 00, 00, time.UTC)},
 		},
+		{
+			name: "update from values",
+			sqb: UpdateStmt{
+				Table: TableIdentifier("users"),
+				Set: SetStmt{
+					{Key: Column("name"), Value: Column("v.name")},
+				},
+				From: UpdateFromStmt{
+					Table: InsertValuesStmt{{Arg{V: 1}, Arg{V: "alice"}}}.As("v", Column("id"), Column("name")),
+					On:    Eq(Column("users.id"), Column("v.id")),
+				},
+				WhereStmt: WhereStmt{Exprs: []BoolExpr{Eq(Column("users.active"), Arg{V: true})}},
+			},
+			expectedRawSQL: "UPDATE users SET name = v.name FROM (VALUES (?, ?)) AS v(id, name) WHERE (users.id=v.id) AND (users.active=?)",
+			expectedArgs:   []interface{}{1, "alice", true},
+		},
+		{
+			name: "update from without condition",
+			sqb: UpdateStmt{
+				Table: TableIdentifier("users"),
+				Set: SetStmt{
+					{Key: Column("city"), Value: Column("cities.name")},
+				},
+				From:      UpdateFromStmt{Table: TableName("cities")},
+				WhereStmt: WhereStmt{Exprs: []BoolExpr{Eq(Column("users.city_id"), Column("cities.id"))}},
+			},
+			expectedRawSQL: "UPDATE users SET city = cities.name FROM cities WHERE (users.city_id=cities.id)",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -42,6 +70,61 @@ func TestUpdateStmt_WriteSQLTo(t *testing.T) {
 				t.Errorf("WriteSQLTo() raw SQL expected = %v, actual = %v", tt.expectedRawSQL, builded)
 			}
 			assert.Equal(t, tt.expectedArgs, tsw.Args)
+			assertRoundTrip(t, DefaultDialect{}, tt.sqb)
+		})
+	}
+}
+
+func TestUpdateStmt_WriteSQLToMySQL(t *testing.T) {
+	tests := []struct {
+		name           string
+		sqb            SQB
+		wantErr        bool
+		expectedRawSQL string
+		expectedArgs   []interface{}
+	}{
+		{
+			name: "update join",
+			sqb: UpdateStmt{
+				Table: TableIdentifier("users"),
+				Set: SetStmt{
+					{Key: Column("users.city"), Value: Column("cities.name")},
+				},
+				From: UpdateFromStmt{
+					Table: TableName("cities"),
+					On:    Eq(Column("users.city_id"), Column("cities.id")),
+				},
+				WhereStmt: WhereStmt{Exprs: []BoolExpr{Eq(Column("users.active"), Arg{V: true})}},
+			},
+			expectedRawSQL: "UPDATE users INNER JOIN cities ON users.city_id=cities.id SET users.city = cities.name WHERE (users.active=?)",
+			expectedArgs:   []interface{}{true},
+		},
+		{
+			name: "update join without condition",
+			sqb: UpdateStmt{
+				Table: TableIdentifier("users"),
+				Set: SetStmt{
+					{Key: Column("users.city"), Value: Column("cities.name")},
+				},
+				From:      UpdateFromStmt{Table: TableName("cities")},
+				WhereStmt: WhereStmt{Exprs: []BoolExpr{Eq(Column("users.city_id"), Column("cities.id"))}},
+			},
+			expectedRawSQL: "UPDATE users CROSS JOIN cities SET users.city = cities.name WHERE (users.city_id=cities.id)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqb := tt.sqb
+			tsw := &MySQLWriter{}
+			if err := sqb.WriteSQLTo(tsw); (err != nil) != tt.wantErr {
+				t.Errorf("WriteSQLTo() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			builded := tsw.String()
+			if builded != tt.expectedRawSQL {
+				t.Errorf("WriteSQLTo() raw SQL expected = %v, actual = %v", tt.expectedRawSQL, builded)
+			}
+			assert.Equal(t, tt.expectedArgs, tsw.Args)
+			assertRoundTrip(t, MySQLDialect{}, tt.sqb)
 		})
 	}
 }
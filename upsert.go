@@ -0,0 +1,96 @@
+package sqb
+
+// OnConflictStmt is upsert clause of PostgreSQL and SQLite:
+// ON CONFLICT (Target) DO NOTHING or DO UPDATE SET ... WHERE ...
+// Values of proposed row are referenced by Excluded.
+// SELECT source without WHERE gets WHERE TRUE in SQLite, which needs it to parse ON CONFLICT.
+type OnConflictStmt struct {
+	Target []Column
+	// DoNothing skips conflicting row, otherwise it is updated with Set.
+	DoNothing bool
+	Set       SetStmt
+	WhereStmt WhereStmt
+}
+
+func (oc OnConflictStmt) Empty() bool {
+	return !oc.DoNothing && len(oc.Set) == 0
+}
+
+// Excluded is column of row proposed for insertion in ON CONFLICT DO UPDATE.
+func Excluded(c Column) Column {
+	return "excluded." + c
+}
+
+// OnConflictDoNothing skips rows conflicting on target columns,
+// or on any unique constraint if target is empty.
+func (is InsertStmt) OnConflictDoNothing(target ...Column) InsertStmt {
+	is.OnConflictStmt = OnConflictStmt{Target: target, DoNothing: true}
+	return is
+}
+
+// OnConflictDoUpdate updates existing rows conflicting on target columns,
+// which match where if it is given.
+func (is InsertStmt) OnConflictDoUpdate(target []Column, set SetStmt, where ...BoolExpr) InsertStmt {
+	is.OnConflictStmt = OnConflictStmt{Target: target, Set: set, WhereStmt: WhereStmt{Exprs: where}}
+	return is
+}
+
+func (oc OnConflictStmt) WriteSQLTo(w SQLWriter) error {
+	if isMySQL(w) {
+		return unsupported(w, oc, "", "ON CONFLICT")
+	}
+	if err := sqliteUnsupported(w, oc, "", "ON CONFLICT", sqliteUpsert); err != nil {
+		return err
+	}
+
+	_, err := w.WriteString("ON CONFLICT")
+	if err != nil {
+		return err
+	}
+
+	if len(oc.Target) > 0 {
+		_, err = w.WriteString(" (")
+		if err != nil {
+			return err
+		}
+		for i, c := range oc.Target {
+			if i > 0 {
+				_, err = w.WriteString(", ")
+				if err != nil {
+					return err
+				}
+			}
+			err = c.WriteSQLTo(w)
+			if err != nil {
+				return err
+			}
+		}
+		_, err = w.WriteString(")")
+		if err != nil {
+			return err
+		}
+	}
+
+	if oc.DoNothing {
+		_, err = w.WriteString(" DO NOTHING")
+		return err
+	}
+
+	_, err = w.WriteString(" DO UPDATE ")
+	if err != nil {
+		return err
+	}
+	err = oc.Set.WriteSQLTo(w)
+	if err != nil {
+		return err
+	}
+
+	if oc.WhereStmt.Empty() {
+		return nil
+	}
+	_, err = w.WriteString(" ")
+	if err != nil {
+		return err
+	}
+	return oc.WhereStmt.WriteSQLTo(w)
+}
@@ -0,0 +1,287 @@
+package sqb
+
+import "strings"
+
+// Validate checks whole statement tree and reports all problems,
+// which otherwise render into invalid SQL or fail rendering.
+func Validate(s SQB) error {
+	if s == nil {
+		return &ValidationError{Problems: []error{&NilNodeError{Field: "statement"}}}
+	}
+
+	var path [8]childName
+	v := validator{root: s, path: path[:0]}
+	v.validate(s)
+	if len(v.problems) == 0 {
+		return nil
+	}
+	return &ValidationError{Problems: v.problems}
+}
+
+func (s SelectStmt) Validate() error {
+	return Validate(s)
+}
+
+func (is InsertStmt) Validate() error {
+	return Validate(is)
+}
+
+func (us UpdateStmt) Validate() error {
+	return Validate(us)
+}
+
+func (bu BatchUpdateStmt) Validate() error {
+	return Validate(bu)
+}
+
+func (ds DeleteStmt) Validate() error {
+	return Validate(ds)
+}
+
+// validator keeps path to current node as names of children,
+// path string is built only for problems found.
+type validator struct {
+	root     SQB
+	path     []childName
+	problems []error
+}
+
+func (v *validator) validate(node SQB) {
+	for _, p := range check(node) {
+		switch e := p.(type) {
+		case *InvalidStatementError:
+			e.Path = v.pathString()
+		case *NilNodeError:
+			e.Path = v.pathString()
+		}
+		v.problems = append(v.problems, p)
+	}
+
+	eachChild(node, func(name childName, c SQB) {
+		v.path = append(v.path, name)
+		v.validate(c)
+		v.path = v.path[:len(v.path)-1]
+	})
+}
+
+func (v *validator) pathString() string {
+	var b strings.Builder
+	b.WriteString(nodeName(v.root))
+	for _, cn := range v.path {
+		name := cn.String()
+		if !strings.HasPrefix(name, "[") {
+			b.WriteByte('.')
+		}
+		b.WriteString(name)
+	}
+	return b.String()
+}
+
+type checks struct {
+	node     SQB
+	problems []error
+}
+
+func (cs *checks) add(failed bool, msg string) {
+	if failed {
+		cs.problems = append(cs.problems, invalid(cs.node, msg))
+	}
+}
+
+func (cs *checks) required(child SQB, field string) {
+	if child == nil {
+		cs.problems = append(cs.problems, nilNode(cs.node, field))
+	}
+}
+
+// check reports problems of node itself, children are checked separately.
+func check(node SQB) []error {
+	cs := checks{node: node}
+	switch n := node.(type) {
+	case unfilteredJoin:
+		cs.problems = append(cs.problems, n.err())
+	case unfilteredTable:
+		cs.problems = append(cs.problems, n.err())
+	case TableIdentifier:
+		cs.add(n == "", "empty table name")
+	case Column:
+		cs.add(n == "", "empty column name")
+	case ColumnList:
+		for _, c := range n.Cols {
+			cs.required(c, "column")
+		}
+	case ColumnAlias:
+		cs.required(n.C, "C")
+		cs.add(n.AS == "", "empty alias")
+	case QualifiedCol:
+		cs.required(n.C, "C")
+	case ReturningStmt:
+		cs.required(n.Cols, "Cols")
+	case SelectStmt:
+		cs.required(n.From, "From")
+		cs.add(!n.OffsetStmt.Empty() && n.LimitStmt.Empty(), "OFFSET without LIMIT")
+	case SubqueryAlias:
+		cs.add(n.AS == "", "empty alias")
+	case HintedTable:
+		cs.required(n.Table, "Table")
+		switch n.Table.(type) {
+		case TableIdentifier, TableIdentifierAlias, nil:
+		default:
+			cs.add(true, "index hints of "+nodeName(n.Table))
+		}
+		for _, h := range n.Hints {
+			cs.add(h.Kind != HintUse && h.Kind != HintForce && h.Kind != HintIgnore, "unknown index hint")
+			cs.add(len(h.Indexes) == 0, "index hint without indexes")
+		}
+	case TableIdentifierAlias:
+		cs.add(n.AS == "", "empty alias")
+	case JoinBuilder:
+		cs.required(n.Joinable, "Joinable")
+	case InnerJoinStmt:
+		cs.checkJoin(n.joinStmtWithOn)
+	case LeftJoinStmt:
+		cs.checkJoin(n.joinStmtWithOn)
+	case RightJoinStmt:
+		cs.checkJoin(n.joinStmtWithOn)
+	case FullOuterJoinStmt:
+		cs.checkJoin(n.joinStmtWithOn)
+	case CrossJoinStmt:
+		cs.required(n.LeftTable, "LeftTable")
+		cs.required(n.RightTable, "RightTable")
+		cs.add(n.discardedOn != nil, "CROSS JOIN does not take ON condition")
+	case ValuesTable:
+		cs.add(n.AS == "", "empty alias")
+		cs.add(len(n.Values) == 0, "VALUES without rows")
+		if len(n.Columns) > 0 {
+			for _, line := range n.Values {
+				if len(line) != len(n.Columns) {
+					cs.add(true, "row length differs from number of column aliases")
+					break
+				}
+			}
+		}
+	case WhereStmt:
+		for _, e := range n.Exprs {
+			cs.required(e, "expression")
+		}
+	case EqExpr:
+		cs.required(n.A, "A")
+		cs.required(n.B, "B")
+	case OrExpr:
+		cs.add(len(n.Exprs) == 0, "OR without expressions")
+		for _, e := range n.Exprs {
+			cs.required(e, "expression")
+		}
+	case AndExpr:
+		cs.add(len(n.Exprs) == 0, "AND without expressions")
+		for _, e := range n.Exprs {
+			cs.required(e, "expression")
+		}
+	case OnOrExpr:
+		cs.add(len(n.Exprs) == 0, "OR without expressions")
+		for _, e := range n.Exprs {
+			cs.required(e, "expression")
+		}
+	case OnAndExpr:
+		cs.add(len(n.Exprs) == 0, "AND without expressions")
+		for _, e := range n.Exprs {
+			cs.required(e, "expression")
+		}
+	case OnBoolExpr:
+		cs.required(n.Expr, "Expr")
+	case OnInExpr:
+		cs.required(n.Some, "Some")
+		cs.add(len(n.In) == 0, "IN without values")
+	case InExpr:
+		cs.required(n.A, "A")
+		cs.add(len(n.List) == 0, "IN without values")
+		for _, e := range n.List {
+			cs.required(e, "value")
+		}
+	case NullCheck:
+		cs.required(n.A, "A")
+	case BinaryOperator:
+		cs.required(n.Left, "Left")
+		cs.required(n.Right, "Right")
+		cs.add(n.Op == "", "empty operator")
+	case NotExpr:
+		cs.required(n.expr, "Expr")
+	case OrderByElem:
+		cs.required(n.C, "C")
+		if n.Kind != AscOrder && n.Kind != DescOrder {
+			cs.add(true, "unknown order "+string(n.Kind))
+		}
+	case GroupByStmt:
+		for _, c := range n.Cols {
+			cs.required(c, "column")
+		}
+	case AggrFuncCall:
+		cs.add(n.Name == "", "empty function name")
+		for _, a := range n.Args {
+			cs.required(a, "argument")
+		}
+	case CaseExpr:
+		cs.add(len(n.Whens) == 0, "CASE without WHEN")
+		for _, wc := range n.Whens {
+			cs.required(wc.When, "WHEN")
+			cs.required(wc.Then, "THEN")
+		}
+	case NamedArg:
+		if err := n.checkName(); err != nil {
+			cs.problems = append(cs.problems, err)
+		}
+	case CastExpr:
+		cs.required(n.A, "A")
+		cs.add(n.Type == "", "empty type")
+	case OnConflictStmt:
+		cs.add(n.DoNothing && len(n.Set) > 0, "DO NOTHING with SET")
+		cs.add(len(n.Set) > 0 && len(n.Target) == 0, "DO UPDATE without conflict target")
+		cs.add(len(n.Set) == 0 && !n.WhereStmt.Empty(), "WHERE without DO UPDATE")
+	case InsertStmt:
+		cs.required(n.Source, "Source")
+		if n.Modifier != "" && n.Modifier != InsertIgnore && n.Modifier != InsertReplace {
+			cs.add(true, "unknown modifier "+string(n.Modifier))
+		}
+		if values, ok := n.Source.(InsertValuesStmt); ok {
+			cs.add(len(values) == 0 && len(n.Columns) > 0, "DEFAULT VALUES with columns")
+			if len(n.Columns) > 0 {
+				for _, line := range values {
+					if len(line) != len(n.Columns) {
+						cs.add(true, "row length differs from number of columns")
+						break
+					}
+				}
+			}
+		}
+	case InsertValuesStmt:
+		for _, line := range n {
+			cs.add(len(line) == 0, "empty row")
+			if len(line) != len(n[0]) {
+				cs.add(true, "rows of different length")
+				break
+			}
+		}
+	case UpdateStmt:
+		cs.add(len(n.Set) == 0, "empty SET")
+		cs.add(n.From.Table == nil && n.From.On != nil, "From.On without From.Table")
+	case SetArg:
+		cs.required(n.Value, "Value")
+	case BatchUpdateStmt:
+		cs.add(len(n.Columns) == 0, "no columns to update")
+		cs.add(len(n.Rows) == 0, "no rows")
+		cs.add(len(n.Types) != 0 && len(n.Types) != len(n.Columns)+1, "number of types differs from number of key and columns")
+		for _, row := range n.Rows {
+			if len(row) != len(n.Columns)+1 {
+				cs.add(true, "row length differs from number of key and columns")
+				break
+			}
+		}
+	}
+	return cs.problems
+}
+
+func (cs *checks) checkJoin(jso joinStmtWithOn) {
+	cs.required(jso.LeftTable, "LeftTable")
+	cs.required(jso.RightTable, "RightTable")
+	cs.required(jso.on, "On")
+}
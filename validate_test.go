@@ -0,0 +1,101 @@
+package sqb
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		sqb      SQB
+		expected []error
+	}{
+		{
+			name: "valid select",
+			sqb:  From(TableName("users")).Where(Or(Eq(Column("a"), Arg{V: 1}), Eq(Column("b"), Arg{V: 2}))).Limit(1).Offset(2),
+		},
+		{
+			name: "offset without limit",
+			sqb:  From(TableName("users")).Offset(10),
+			expected: []error{
+				&InvalidStatementError{Node: "SelectStmt", Path: "SelectStmt", Msg: "OFFSET without LIMIT"},
+			},
+		},
+		{
+			name: "nil from",
+			sqb:  SelectStmt{},
+			expected: []error{
+				&NilNodeError{Node: "SelectStmt", Path: "SelectStmt", Field: "From"},
+			},
+		},
+		{
+			name: "empty or and and",
+			sqb:  From(TableName("users")).Where(Or(), Not(And())),
+			expected: []error{
+				&InvalidStatementError{Node: "OrExpr", Path: "SelectStmt.WhereStmt.Exprs[0]", Msg: "OR without expressions"},
+				&InvalidStatementError{Node: "AndExpr", Path: "SelectStmt.WhereStmt.Exprs[1].Expr", Msg: "AND without expressions"},
+			},
+		},
+		{
+			name: "empty set",
+			sqb:  UpdateStmt{Table: TableName("users"), WhereStmt: WhereStmt{Exprs: []BoolExpr{Eq(Column("id"), Arg{V: 1})}}},
+			expected: []error{
+				&InvalidStatementError{Node: "UpdateStmt", Path: "UpdateStmt", Msg: "empty SET"},
+			},
+		},
+		{
+			name: "cross join with on",
+			sqb: From(JB(TableName("users")).CrossJoin(TableName("cities"), Eq(Column("users.city_id"), Column("cities.id")))).
+				Where(Eq(nil, Arg{V: 1})),
+			expected: []error{
+				&InvalidStatementError{Node: "CrossJoinStmt", Path: "SelectStmt.From.Joinable", Msg: "CROSS JOIN does not take ON condition"},
+				&NilNodeError{Node: "EqExpr", Path: "SelectStmt.WhereStmt.Exprs[0]", Field: "A"},
+			},
+		},
+		{
+			name: "subquery",
+			sqb:  From(From(TableName("")).As("u")),
+			expected: []error{
+				&InvalidStatementError{Node: "TableIdentifier", Path: "SelectStmt.From.SelectStmt.From", Msg: "empty table name"},
+			},
+		},
+		{
+			name: "insert rows",
+			sqb:  Insert(TableName("users"), []Column{"id", "name"}, InsertValuesStmt{{Arg{V: 1}}}),
+			expected: []error{
+				&InvalidStatementError{Node: "InsertStmt", Path: "InsertStmt", Msg: "row length differs from number of columns"},
+			},
+		},
+		{
+			name: "set value",
+			sqb:  UpdateStmt{Table: TableName("users"), Set: SetStmt{{Key: Column("name")}}},
+			expected: []error{
+				&NilNodeError{Node: "SetArg", Path: "UpdateStmt.Set[0]", Field: "Value"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.sqb)
+			if tt.expected == nil {
+				assert.NoError(t, err)
+				return
+			}
+
+			var ve *ValidationError
+			if assert.True(t, errors.As(err, &ve)) {
+				assert.Equal(t, tt.expected, ve.Problems)
+			}
+
+			_, _, err = ToSQL(tt.sqb)
+			assert.True(t, errors.As(err, &ve), "ToSQL must validate")
+		})
+	}
+}
+
+func TestSelectStmt_WriteSQLToWithoutFrom(t *testing.T) {
+	assert.Error(t, SelectStmt{}.WriteSQLTo(&DefaultSQLWriter{}))
+}
@@ -0,0 +1,84 @@
+package sqb
+
+// ValuesTable is VALUES list used as table source:
+// (VALUES (1, 'a'), (2, 'b')) AS v(id, name)
+// MySQL gets rows as ROW(1, 'a').
+type ValuesTable struct {
+	Values  InsertValuesStmt
+	AS      string
+	Columns []Column
+}
+
+func (ValuesTable) IsTable()    {}
+func (ValuesTable) IsJoinable() {}
+
+// As makes VALUES list usable as table with name and optional column aliases.
+func (ivs InsertValuesStmt) As(name string, columns ...Column) ValuesTable {
+	return ValuesTable{
+		Values:  ivs,
+		AS:      name,
+		Columns: columns,
+	}
+}
+
+func (vt ValuesTable) WriteSQLTo(w SQLWriter) error {
+	if len(vt.Values) == 0 {
+		return invalid(vt, "VALUES table must have at least one row")
+	}
+
+	_, err := w.WriteString("(")
+	if err != nil {
+		return err
+	}
+
+	row := ""
+	if isMySQL(w) {
+		row = "ROW"
+	}
+	err = vt.Values.writeRows(w, row)
+	if err != nil {
+		return err
+	}
+
+	_, err = w.WriteString(") AS ")
+	if err != nil {
+		return err
+	}
+
+	err = writeAlias(w, vt.AS)
+	if err != nil {
+		return err
+	}
+
+	if len(vt.Columns) == 0 {
+		return nil
+	}
+	if isSQLite(w) {
+		return unsupported(w, vt, "Columns", "column aliases of VALUES")
+	}
+
+	_, err = w.WriteString("(")
+	if err != nil {
+		return err
+	}
+
+	err = vt.Columns[0].WriteSQLTo(w)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range vt.Columns[1:] {
+		_, err = w.WriteString(", ")
+		if err != nil {
+			return err
+		}
+
+		err = c.WriteSQLTo(w)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = w.WriteString(")")
+	return err
+}
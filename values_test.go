@@ -0,0 +1,69 @@
+package sqb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValuesTable_WriteSQLTo(t *testing.T) {
+	tests := []struct {
+		name           string
+		sqb            SQB
+		wantErr        bool
+		expectedRawSQL string
+		expectedArgs   []interface{}
+	}{
+		{
+			name: "select from values",
+			sqb: From(InsertValuesStmt{
+				{Arg{V: 1}, Arg{V: "a"}},
+				{Arg{V: 2}, Arg{V: "b"}},
+			}.As("v", Column("id"), Column("name"))),
+			expectedRawSQL: "SELECT * FROM (VALUES (?, ?), (?, ?)) AS v(id, name)",
+			expectedArgs:   []interface{}{1, "a", 2, "b"},
+		},
+		{
+			name:           "values without column aliases",
+			sqb:            From(InsertValuesStmt{{Arg{V: 1}}}.As("v")),
+			expectedRawSQL: "SELECT * FROM (VALUES (?)) AS v",
+			expectedArgs:   []interface{}{1},
+		},
+		{
+			name: "join with values",
+			sqb: From(
+				InnerJoin(
+					TableName("users"),
+					InsertValuesStmt{{Arg{V: 1}, Arg{V: 10}}}.As("v", Column("id"), Column("score")),
+					Eq(Column("users.id"), Column("v.id")),
+				),
+			).Select(Column("users.name"), Column("v.score")),
+			expectedRawSQL: "SELECT users.name, v.score FROM users INNER JOIN (VALUES (?, ?)) AS v(id, score) ON users.id=v.id",
+			expectedArgs:   []interface{}{1, 10},
+		},
+		{
+			name:    "empty values",
+			sqb:     From(InsertValuesStmt{}.As("v")),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sqb := tt.sqb
+			tsw := &DefaultSQLWriter{}
+			err := sqb.WriteSQLTo(tsw)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("WriteSQLTo() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			builded := tsw.String()
+			if builded != tt.expectedRawSQL {
+				t.Errorf("WriteSQLTo() raw SQL expected = %v, actual = %v", tt.expectedRawSQL, builded)
+			}
+			assert.Equal(t, tt.expectedArgs, tsw.Args)
+			assertRoundTrip(t, DefaultDialect{}, tt.sqb)
+		})
+	}
+}
@@ -0,0 +1,334 @@
+package sqb
+
+import "strconv"
+
+// Child is child node with name of field holding it, like "From" or "Exprs[1]".
+type Child struct {
+	Name string
+	Node SQB
+}
+
+// Node is implemented by statement tree nodes unknown to Children,
+// so Walk can descend into them.
+type Node interface {
+	SQB
+	Children() []Child
+}
+
+// Visitor is called by Walk for every node.
+// If returned visitor w is not nil, Walk visits children of node with w
+// followed by call of w.Visit(nil).
+type Visitor interface {
+	Visit(node SQB) (w Visitor)
+}
+
+// Walk traverses statement tree in depth-first order.
+func Walk(v Visitor, node SQB) {
+	if v = v.Visit(node); v == nil {
+		return
+	}
+	eachChild(node, func(_ childName, c SQB) {
+		Walk(v, c)
+	})
+	v.Visit(nil)
+}
+
+type inspector func(SQB) bool
+
+func (f inspector) Visit(node SQB) Visitor {
+	if f(node) {
+		return f
+	}
+	return nil
+}
+
+// Inspect traverses statement tree in depth-first order calling f for every node.
+// If f returns true, Inspect visits children of node, followed by call of f(nil).
+func Inspect(node SQB, f func(SQB) bool) {
+	Walk(inspector(f), node)
+}
+
+func (js joinStmt) Kind() string {
+	return js.kind
+}
+
+func (jso joinStmtWithOn) On() OnExpr {
+	return jso.on
+}
+
+func (ne NotExpr) Expr() BoolExpr {
+	return ne.expr
+}
+
+// Children returns direct child nodes of node in rendering order,
+// empty clauses and nil nodes are omitted.
+func Children(node SQB) []Child {
+	var cs []Child
+	eachChild(node, func(name childName, c SQB) {
+		cs = append(cs, Child{Name: name.String(), Node: c})
+	})
+	return cs
+}
+
+// childName is name of field holding child, formatted only when needed as field[i][j]sub.
+type childName struct {
+	field string
+	index [2]int
+	n     int
+	sub   string
+}
+
+func (cn childName) String() string {
+	if cn.n == 0 && cn.sub == "" {
+		return cn.field
+	}
+	b := make([]byte, 0, len(cn.field)+len(cn.sub)+8)
+	b = append(b, cn.field...)
+	for _, i := range cn.index[:cn.n] {
+		b = append(b, '[')
+		b = strconv.AppendInt(b, int64(i), 10)
+		b = append(b, ']')
+	}
+	b = append(b, cn.sub...)
+	return string(b)
+}
+
+// eachChild calls f for every child of node like Children does,
+// but without building names and slice of children.
+func eachChild(node SQB, f func(childName, SQB)) {
+	cs := children{f: f}
+	switch n := node.(type) {
+	case Node:
+		for _, c := range n.Children() {
+			f(childName{field: c.Name}, c.Node)
+		}
+	case ColumnList:
+		for i, c := range n.Cols {
+			cs.addAt("Cols", i, c)
+		}
+	case ReturningStmt:
+		cs.add("Cols", n.Cols)
+	case SelectStmt:
+		cs.addSelect(n)
+	case SubqueryAlias:
+		cs.add("SelectStmt", n.SelectStmt)
+	case HintedTable:
+		cs.add("Table", n.Table)
+	case TableIdentifierAlias:
+		cs.add("TableIdentifier", n.TableIdentifier)
+	case JoinBuilder:
+		cs.add("Joinable", n.Joinable)
+	case InnerJoinStmt:
+		cs.addJoin(n.joinStmtWithOn)
+	case LeftJoinStmt:
+		cs.addJoin(n.joinStmtWithOn)
+	case RightJoinStmt:
+		cs.addJoin(n.joinStmtWithOn)
+	case FullOuterJoinStmt:
+		cs.addJoin(n.joinStmtWithOn)
+	case CrossJoinStmt:
+		cs.add("LeftTable", n.LeftTable)
+		cs.add("RightTable", n.RightTable)
+	case ValuesTable:
+		cs.add("Values", n.Values)
+	case WhereStmt:
+		for i, e := range n.Exprs {
+			cs.addAt("Exprs", i, e)
+		}
+	case EqExpr:
+		cs.add("A", n.A)
+		cs.add("B", n.B)
+	case OrExpr:
+		for i, e := range n.Exprs {
+			cs.addAt("Exprs", i, e)
+		}
+	case AndExpr:
+		for i, e := range n.Exprs {
+			cs.addAt("Exprs", i, e)
+		}
+	case OnOrExpr:
+		for i, e := range n.Exprs {
+			cs.addAt("Exprs", i, e)
+		}
+	case OnAndExpr:
+		for i, e := range n.Exprs {
+			cs.addAt("Exprs", i, e)
+		}
+	case OnBoolExpr:
+		cs.add("Expr", n.Expr)
+	case OnInExpr:
+		cs.add("Some", n.Some)
+		for i, e := range n.In {
+			cs.addAt("In", i, e)
+		}
+	case InExpr:
+		cs.add("A", n.A)
+		for i, e := range n.List {
+			cs.addAt("List", i, e)
+		}
+	case NullCheck:
+		cs.add("A", n.A)
+	case BinaryOperator:
+		cs.add("Left", n.Left)
+		cs.add("Right", n.Right)
+	case ExistsStmt:
+		cs.add("Select", n.Select)
+	case NotExpr:
+		cs.add("Expr", n.expr)
+	case OrderByStmt:
+		for i, e := range n.Elems {
+			cs.addAt("Elems", i, e)
+		}
+	case OrderByElem:
+		cs.add("C", n.C)
+	case GroupByStmt:
+		for i, c := range n.Cols {
+			cs.addAt("Cols", i, c)
+		}
+	case AggrFuncCall:
+		for i, a := range n.Args {
+			cs.addAt("Args", i, a)
+		}
+	case CaseExpr:
+		cs.add("Operand", n.Operand)
+		for i, wc := range n.Whens {
+			cs.addSub("Whens", i, ".When", wc.When)
+			cs.addSub("Whens", i, ".Then", wc.Then)
+		}
+		cs.add("Else", n.Else)
+	case CastExpr:
+		cs.add("A", n.A)
+	case ColumnAlias:
+		cs.add("C", n.C)
+	case QualifiedCol:
+		cs.add("C", n.C)
+	case InsertStmt:
+		cs.add("Table", n.Table)
+		for i, c := range n.Columns {
+			cs.addAt("Columns", i, c)
+		}
+		cs.add("Source", n.Source)
+		if !n.OnConflictStmt.Empty() {
+			cs.add("OnConflictStmt", n.OnConflictStmt)
+		}
+		if n.ReturningStmt.Cols != nil {
+			cs.add("ReturningStmt", n.ReturningStmt)
+		}
+	case InsertValuesStmt:
+		for i, line := range n {
+			for j, v := range line {
+				cs.addAt2("", i, j, v)
+			}
+		}
+	case UpdateStmt:
+		cs.add("Table", n.Table)
+		cs.add("Set", n.Set)
+		if n.From.Table != nil {
+			cs.add("From.Table", n.From.Table)
+		}
+		if n.From.On != nil {
+			cs.add("From.On", n.From.On)
+		}
+		if !n.WhereStmt.Empty() {
+			cs.add("WhereStmt", n.WhereStmt)
+		}
+		if n.ReturningStmt.Cols != nil {
+			cs.add("ReturningStmt", n.ReturningStmt)
+		}
+	case DeleteStmt:
+		cs.add("Table", n.Table)
+		if !n.WhereStmt.Empty() {
+			cs.add("WhereStmt", n.WhereStmt)
+		}
+		if n.ReturningStmt.Cols != nil {
+			cs.add("ReturningStmt", n.ReturningStmt)
+		}
+	case OnConflictStmt:
+		for i, c := range n.Target {
+			cs.addAt("Target", i, c)
+		}
+		if len(n.Set) > 0 {
+			cs.add("Set", n.Set)
+		}
+		if !n.WhereStmt.Empty() {
+			cs.add("WhereStmt", n.WhereStmt)
+		}
+	case SetStmt:
+		for i, sa := range n {
+			cs.addAt("", i, sa)
+		}
+	case SetArg:
+		cs.add("Key", n.Key)
+		cs.add("Value", n.Value)
+	case BatchUpdateStmt:
+		cs.add("Table", n.Table)
+		cs.add("Key", n.Key)
+		for i, c := range n.Columns {
+			cs.addAt("Columns", i, c)
+		}
+		if !n.WhereStmt.Empty() {
+			cs.add("WhereStmt", n.WhereStmt)
+		}
+	}
+}
+
+type children struct {
+	f func(childName, SQB)
+}
+
+func (cs *children) addName(name childName, node SQB) {
+	if node == nil {
+		return
+	}
+	cs.f(name, node)
+}
+
+func (cs *children) add(field string, node SQB) {
+	cs.addName(childName{field: field}, node)
+}
+
+func (cs *children) addAt(field string, i int, node SQB) {
+	cs.addName(childName{field: field, index: [2]int{i}, n: 1}, node)
+}
+
+func (cs *children) addAt2(field string, i, j int, node SQB) {
+	cs.addName(childName{field: field, index: [2]int{i, j}, n: 2}, node)
+}
+
+func (cs *children) addSub(field string, i int, sub string, node SQB) {
+	cs.addName(childName{field: field, index: [2]int{i}, n: 1, sub: sub}, node)
+}
+
+func (cs *children) addJoin(jso joinStmtWithOn) {
+	cs.add("LeftTable", jso.LeftTable)
+	cs.add("RightTable", jso.RightTable)
+	cs.add("On", jso.on)
+}
+
+func (cs *children) addSelect(s SelectStmt) {
+	cs.add("Cols", s.Cols)
+	cs.add("From", s.From)
+	if !s.WhereStmt.Empty() {
+		cs.add("WhereStmt", s.WhereStmt)
+	}
+	if !s.GroupByStmt.Empty() {
+		cs.add("GroupByStmt", s.GroupByStmt)
+	}
+	if !s.OrderByStmt.Empty() {
+		cs.add("OrderByStmt", s.OrderByStmt)
+	}
+	if !s.LimitStmt.Empty() {
+		cs.add("LimitStmt", s.LimitStmt)
+	}
+	if !s.OffsetStmt.Empty() {
+		cs.add("OffsetStmt", s.OffsetStmt)
+	}
+}
+
+func (tc TypedColumn[T]) Children() []Child {
+	return []Child{{Name: "Column", Node: tc.Column}}
+}
+
+func (ta TypedArg[T]) Children() []Child {
+	return []Child{{Name: "Arg", Node: ta.Arg}}
+}
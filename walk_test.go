@@ -0,0 +1,98 @@
+package sqb
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInspect(t *testing.T) {
+	s := From(
+		JB(TableName("users").As("u")).
+			LeftJoin(From(TableName("posts")).Where(Eq(Column("posts.published"), Arg{V: true})).As("p"), Eq(Column("u.id"), Column("p.user_id"))),
+	).Select(Column("u.name"), Count(Column("p.id"))).
+		Where(Not(Or(Eq(Column("u.city"), Arg{V: "Oslo"}), NullCheck{A: Column("u.city"), IsNull: true}))).
+		GroupBy(Column("u.name")).
+		OrderBy(Desc(Column("u.name")))
+
+	var (
+		tables  []TableIdentifier
+		columns []Column
+		args    []interface{}
+	)
+	Inspect(s, func(node SQB) bool {
+		switch n := node.(type) {
+		case TableIdentifier:
+			tables = append(tables, n)
+		case Column:
+			columns = append(columns, n)
+		case Arg:
+			args = append(args, n.V)
+		}
+		return true
+	})
+
+	assert.Equal(t, []TableIdentifier{"users", "posts"}, tables)
+	assert.Equal(t, []Column{
+		"u.name", "p.id", "posts.published", "u.id", "p.user_id", "u.city", "u.city", "u.name", "u.name",
+	}, columns)
+	assert.Equal(t, []interface{}{true, "Oslo"}, args)
+}
+
+type depthVisitor struct {
+	depth    int
+	maxDepth *int
+}
+
+func (v depthVisitor) Visit(node SQB) Visitor {
+	if node == nil {
+		return nil
+	}
+	if v.depth > *v.maxDepth {
+		*v.maxDepth = v.depth
+	}
+	return depthVisitor{depth: v.depth + 1, maxDepth: v.maxDepth}
+}
+
+func TestWalk(t *testing.T) {
+	maxDepth := 0
+	Walk(depthVisitor{maxDepth: &maxDepth}, From(TableName("users")).Where(Eq(Column("id"), Arg{V: 1})))
+	// SelectStmt, WhereStmt, EqExpr, Column
+	assert.Equal(t, 3, maxDepth)
+
+	skipped := 0
+	Inspect(From(TableName("users")).Where(ExistsStmt{Select: From(TableName("posts"))}), func(node SQB) bool {
+		if _, ok := node.(ExistsStmt); ok {
+			return false
+		}
+		if _, ok := node.(TableIdentifier); ok {
+			skipped++
+		}
+		return true
+	})
+	assert.Equal(t, 1, skipped)
+}
+
+func TestChildren(t *testing.T) {
+	on := Eq(Column("users.id"), Column("posts.user_id"))
+	join := LeftJoin(TableName("users"), TableName("posts"), on)
+	assert.Equal(t, "LEFT", join.Kind())
+	assert.Equal(t, on, join.On())
+	assert.Equal(t, []Child{
+		{Name: "LeftTable", Node: TableName("users")},
+		{Name: "RightTable", Node: TableName("posts")},
+		{Name: "On", Node: on},
+	}, Children(join))
+
+	not := Not(on)
+	assert.Equal(t, on, not.Expr())
+
+	assert.Equal(t, []Child{
+		{Name: "Table", Node: TableName("users")},
+		{Name: "Columns[0]", Node: Column("id")},
+		{Name: "Source", Node: InsertValuesStmt{{Arg{V: 1}}}},
+	}, Children(Insert(TableName("users"), []Column{"id"}, InsertValuesStmt{{Arg{V: 1}}})))
+
+	assert.Equal(t, []Child{{Name: "Column", Node: Column("id")}}, Children(ColumnOf[int]("id")))
+	assert.Empty(t, Children(Column("id")))
+}